@@ -119,6 +119,37 @@ type (
 		Secret         string `envconfig:"DRONE_DATABASE_SECRET"`
 		MaxConnections int    `envconfig:"DRONE_DATABASE_MAX_CONNECTIONS" default:"0"`
 
+		// Previous database secrets, used to decrypt data that
+		// was encrypted before the database secret was rotated.
+		SecretPrevious []string `envconfig:"DRONE_DATABASE_SECRET_PREVIOUS"`
+
+		// Key management service (awskms, gcpkms or vault) used
+		// to decrypt the database secrets at startup, in which
+		// case the configured secrets are ciphertext.
+		SecretKMS        string        `envconfig:"DRONE_DATABASE_SECRET_KMS"`
+		SecretKMSTimeout time.Duration `envconfig:"DRONE_DATABASE_SECRET_KMS_TIMEOUT" default:"30s"`
+
+		// Google Cloud KMS key resource name, and an optional
+		// access token. If the token is empty it is requested
+		// from the instance metadata server.
+		SecretGCPKey   string `envconfig:"DRONE_DATABASE_SECRET_GCP_KEY"`
+		SecretGCPToken string `envconfig:"DRONE_DATABASE_SECRET_GCP_TOKEN"`
+
+		// Vault transit secrets engine configuration.
+		SecretVaultAddr  string `envconfig:"DRONE_DATABASE_SECRET_VAULT_ADDR"`
+		SecretVaultToken string `envconfig:"DRONE_DATABASE_SECRET_VAULT_TOKEN"`
+		SecretVaultMount string `envconfig:"DRONE_DATABASE_SECRET_VAULT_MOUNT" default:"transit"`
+		SecretVaultKey   string `envconfig:"DRONE_DATABASE_SECRET_VAULT_KEY"`
+
+		// Read-only replica used for list and count queries
+		// that tolerate replication lag.
+		ReplicaDatasource string `envconfig:"DRONE_DATABASE_REPLICA_DATASOURCE"`
+
+		// In-memory cache of repository permission lookups.
+		// The cache is disabled if the ttl is zero.
+		PermCacheSize int           `envconfig:"DRONE_DATABASE_PERM_CACHE_SIZE" default:"1000"`
+		PermCacheTTL  time.Duration `envconfig:"DRONE_DATABASE_PERM_CACHE_TTL"`
+
 		// Feature flag
 		LegacyBatch bool `envconfig:"DRONE_DATABASE_LEGACY_BATCH"`
 
@@ -257,6 +288,8 @@ type (
 		Email string `envconfig:"DRONE_TLS_EMAIL"`
 		Cert  string `envconfig:"DRONE_TLS_CERT"`
 		Key   string `envconfig:"DRONE_TLS_KEY"`
+
+		ShutdownTimeout time.Duration `envconfig:"DRONE_SERVER_SHUTDOWN_TIMEOUT" default:"5s"`
 	}
 
 	// Proxy provides proxy server configuration.
@@ -300,10 +333,13 @@ type (
 
 	// Webhook provides the webhook configuration.
 	Webhook struct {
-		Events     []string `envconfig:"DRONE_WEBHOOK_EVENTS"`
-		Endpoint   []string `envconfig:"DRONE_WEBHOOK_ENDPOINT"`
-		Secret     string   `envconfig:"DRONE_WEBHOOK_SECRET"`
-		SkipVerify bool     `envconfig:"DRONE_WEBHOOK_SKIP_VERIFY"`
+		Events     []string          `envconfig:"DRONE_WEBHOOK_EVENTS"`
+		Endpoint   []string          `envconfig:"DRONE_WEBHOOK_ENDPOINT"`
+		Secret     string            `envconfig:"DRONE_WEBHOOK_SECRET"`
+		Headers    map[string]string `envconfig:"DRONE_WEBHOOK_HEADERS"`
+		Format     string            `envconfig:"DRONE_WEBHOOK_FORMAT"`
+		Timeout    time.Duration     `envconfig:"DRONE_WEBHOOK_TIMEOUT" default:"1m"`
+		SkipVerify bool              `envconfig:"DRONE_WEBHOOK_SKIP_VERIFY"`
 	}
 
 	// Yaml provides the yaml webhook configuration.
@@ -161,11 +161,14 @@ func provideValidatePlugin(conf spec.Config) core.ValidateService {
 
 // provideWebhookPlugin is a Wire provider function that returns
 // a webhook plugin based on the environment configuration.
-func provideWebhookPlugin(config spec.Config, system *core.System) core.WebhookSender {
+func provideWebhookPlugin(config spec.Config, system *core.System) (core.WebhookSender, error) {
 	return webhook.New(webhook.Config{
 		Events:   config.Webhook.Events,
 		Endpoint: config.Webhook.Endpoint,
 		Secret:   config.Webhook.Secret,
+		Headers:  config.Webhook.Headers,
+		Format:   config.Webhook.Format,
+		Timeout:  config.Webhook.Timeout,
 		System:   system,
 	})
 }
@@ -15,11 +15,13 @@
 package main
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/drone/drone/cmd/drone-server/config"
 	"github.com/drone/drone/core"
 	"github.com/drone/drone/handler/api"
+	"github.com/drone/drone/handler/api/maintenance"
 	"github.com/drone/drone/handler/health"
 	"github.com/drone/drone/handler/web"
 	"github.com/drone/drone/metric"
@@ -27,6 +29,8 @@ import (
 	"github.com/drone/drone/operator/manager/rpc"
 	"github.com/drone/drone/operator/manager/rpc2"
 	"github.com/drone/drone/server"
+	"github.com/drone/drone/service/redisdb"
+	"github.com/drone/drone/store/shared/db"
 	"github.com/google/wire"
 
 	"github.com/go-chi/chi"
@@ -36,6 +40,7 @@ import (
 
 type (
 	healthzHandler http.Handler
+	readyzHandler  http.Handler
 	metricsHandler http.Handler
 	pprofHandler   http.Handler
 	rpcHandlerV1   http.Handler
@@ -48,6 +53,7 @@ var serverSet = wire.NewSet(
 	api.New,
 	web.New,
 	provideHealthz,
+	provideReadyz,
 	provideMetric,
 	providePprof,
 	provideRouter,
@@ -59,23 +65,57 @@ var serverSet = wire.NewSet(
 
 // provideRouter is a Wire provider function that returns a
 // router that is serves the provided handlers.
-func provideRouter(api api.Server, web web.Server, rpcv1 rpcHandlerV1, rpcv2 rpcHandlerV2, healthz healthzHandler, metrics *metric.Server, pprof pprofHandler) *chi.Mux {
+func provideRouter(api api.Server, web web.Server, rpcv1 rpcHandlerV1, rpcv2 rpcHandlerV2, healthz healthzHandler, readyz readyzHandler, metrics *metric.Server, pprof pprofHandler) *chi.Mux {
 	r := chi.NewRouter()
 	r.Mount("/healthz", healthz)
+	r.Mount("/readyz", readyz)
 	r.Mount("/metrics", metrics)
-	r.Mount("/api", api.Handler())
+	v1 := api.Handler()
+	r.Mount("/api/v1", v1)
+	r.Mount("/api/v2", api.HandlerV2())
+	r.Mount("/api", v1)
 	r.Mount("/rpc/v2", rpcv2)
 	r.Mount("/rpc", rpcv1)
+	web.Capabilities = api.Capabilities()
 	r.Mount("/", web.Handler())
 	r.Mount("/debug", pprof)
 	return r
 }
 
-// provideMetric is a Wire provider function that returns the
-// healthcheck server.
-func provideHealthz() healthzHandler {
-	v := health.New()
-	return healthzHandler(v)
+// provideHealthz is a Wire provider function that returns the
+// healthcheck server, which verifies the scheduler loop is still
+// running.
+func provideHealthz(sched core.Scheduler) healthzHandler {
+	checks := map[string]health.Checker{}
+	if p, ok := sched.(pinger); ok {
+		checks["scheduler"] = p.Ping
+	}
+	return healthzHandler(health.New(checks))
+}
+
+// provideReadyz is a Wire provider function that returns the
+// readiness check server, which verifies the database and, if
+// configured, the redis server and the log storage bucket are
+// reachable.
+func provideReadyz(db *db.DB, rdb redisdb.RedisDB, logs core.LogStore) readyzHandler {
+	checks := map[string]health.Checker{
+		"database": db.Ping,
+	}
+	if rdb != nil {
+		checks["redis"] = func(ctx context.Context) error {
+			return rdb.Client().Ping(ctx).Err()
+		}
+	}
+	if p, ok := logs.(pinger); ok {
+		checks["logs"] = p.Ping
+	}
+	return readyzHandler(health.NewReady(checks))
+}
+
+// pinger is implemented by components that can report whether
+// they are reachable or running.
+type pinger interface {
+	Ping(context.Context) error
 }
 
 // provideMetric is a Wire provider function that returns the
@@ -99,16 +139,18 @@ func providePprof(config config.Config) pprofHandler {
 
 // provideRPC is a Wire provider function that returns an rpc
 // handler that exposes the build manager to a remote agent.
-func provideRPC(m manager.BuildManager, config config.Config) rpcHandlerV1 {
+// Requests for pending stages are rejected in maintenance mode.
+func provideRPC(m manager.BuildManager, maintenanceService core.MaintenanceService, config config.Config) rpcHandlerV1 {
 	v := rpc.NewServer(m, config.RPC.Secret)
-	return rpcHandlerV1(v)
+	return rpcHandlerV1(maintenance.RejectQueue(maintenanceService, config.RPC.Secret)(v))
 }
 
 // provideRPC2 is a Wire provider function that returns an rpc
 // handler that exposes the build manager to a remote agent.
-func provideRPC2(m manager.BuildManager, config config.Config) rpcHandlerV2 {
+// Requests for pending stages are rejected in maintenance mode.
+func provideRPC2(m manager.BuildManager, maintenanceService core.MaintenanceService, config config.Config) rpcHandlerV2 {
 	v := rpc2.NewServer(m, config.RPC.Secret)
-	return rpcHandlerV2(v)
+	return rpcHandlerV2(maintenance.RejectQueue(maintenanceService, config.RPC.Secret)(v))
 }
 
 // provideServer is a Wire provider function that returns an
@@ -121,6 +163,7 @@ func provideServer(handler *chi.Mux, config config.Config) *server.Server {
 		Key:     config.Server.Key,
 		Host:    config.Server.Host,
 		Handler: handler,
+		Timeout: config.Server.ShutdownTimeout,
 	}
 }
 
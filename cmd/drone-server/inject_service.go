@@ -30,6 +30,7 @@ import (
 	"github.com/drone/drone/service/hook"
 	"github.com/drone/drone/service/hook/parser"
 	"github.com/drone/drone/service/linker"
+	"github.com/drone/drone/service/maintenance"
 	"github.com/drone/drone/service/netrc"
 	orgs "github.com/drone/drone/service/org"
 	"github.com/drone/drone/service/repo"
@@ -54,6 +55,7 @@ var serviceSet = wire.NewSet(
 	cron.New,
 	livelog.New,
 	linker.New,
+	maintenance.New,
 	parser.New,
 	pubsub.New,
 	token.Renewer,
@@ -15,21 +15,28 @@
 package main
 
 import (
+	"context"
+
 	"github.com/drone/drone/cmd/drone-server/config"
 	"github.com/drone/drone/core"
 	"github.com/drone/drone/metric"
+	"github.com/drone/drone/service/redisdb"
+	"github.com/drone/drone/store/banner"
 	"github.com/drone/drone/store/batch"
 	"github.com/drone/drone/store/batch2"
 	"github.com/drone/drone/store/build"
 	"github.com/drone/drone/store/card"
 	"github.com/drone/drone/store/cron"
 	"github.com/drone/drone/store/logs"
+	"github.com/drone/drone/store/maintenance"
+	"github.com/drone/drone/store/orphan"
 	"github.com/drone/drone/store/perm"
 	"github.com/drone/drone/store/repos"
 	"github.com/drone/drone/store/secret"
 	"github.com/drone/drone/store/secret/global"
 	"github.com/drone/drone/store/shared/db"
 	"github.com/drone/drone/store/shared/encrypt"
+	"github.com/drone/drone/store/shared/encrypt/kms"
 	"github.com/drone/drone/store/stage"
 	"github.com/drone/drone/store/step"
 	"github.com/drone/drone/store/template"
@@ -49,12 +56,15 @@ var storeSet = wire.NewSet(
 	provideStageStore,
 	provideUserStore,
 	provideBatchStore,
+	providePermStore,
 	// batch.New,
+	banner.New,
 	cron.New,
 	card.New,
-	perm.New,
 	secret.New,
 	global.New,
+	maintenance.New,
+	orphan.New,
 	step.New,
 	template.New,
 )
@@ -62,17 +72,57 @@ var storeSet = wire.NewSet(
 // provideDatabase is a Wire provider function that provides a
 // database connection, configured from the environment.
 func provideDatabase(config config.Config) (*db.DB, error) {
-	return db.Connect(
+	conn, err := db.Connect(
 		config.Database.Driver,
 		config.Database.Datasource,
 		config.Database.MaxConnections,
 	)
+	if err != nil || config.Database.ReplicaDatasource == "" {
+		return conn, err
+	}
+	// if the read replica cannot be reached the server falls
+	// back to the primary database for all queries.
+	err = conn.ConnectReplica(
+		config.Database.ReplicaDatasource,
+		config.Database.MaxConnections,
+	)
+	if err != nil {
+		logrus.WithError(err).
+			Warnln("main: cannot connect to database replica")
+	} else {
+		logrus.Debugln("main: database replica enabled")
+	}
+	return conn, nil
 }
 
 // provideEncrypter is a Wire provider function that provides a
 // database encrypter, configured from the environment.
 func provideEncrypter(config config.Config) (encrypt.Encrypter, error) {
-	enc, err := encrypt.New(config.Database.Secret)
+	keys := append([]string{config.Database.Secret}, config.Database.SecretPrevious...)
+	// if a key management service is configured the database
+	// secrets are encrypted, and must be decrypted before use.
+	if config.Database.SecretKMS != "" {
+		logrus.WithField("kms", config.Database.SecretKMS).
+			Debugln("main: decrypting database secret")
+		service, err := kms.New(kms.Config{
+			Provider:   config.Database.SecretKMS,
+			Timeout:    config.Database.SecretKMSTimeout,
+			GCPKey:     config.Database.SecretGCPKey,
+			GCPToken:   config.Database.SecretGCPToken,
+			VaultAddr:  config.Database.SecretVaultAddr,
+			VaultToken: config.Database.SecretVaultToken,
+			VaultMount: config.Database.SecretVaultMount,
+			VaultKey:   config.Database.SecretVaultKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keys, err = kms.DecryptAll(context.Background(), service, keys...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	enc, err := encrypt.New(keys[0], keys[1:]...)
 	// mixed-content mode should be set to true if the database
 	// originally had encryption disabled and therefore has
 	// plaintext entries. This prevents Drone from returning an
@@ -142,13 +192,32 @@ func provideRepoStore(db *db.DB) core.RepositoryStore {
 	return repos
 }
 
+// providePermStore is a Wire provider function that provides a
+// permission datastore. Permissions are looked up on nearly every
+// request, so lookups can optionally be cached in memory for a
+// short duration. If redis is configured, cache evictions are
+// broadcast to all server instances.
+func providePermStore(db *db.DB, r redisdb.RedisDB, config config.Config) core.PermStore {
+	perms := perm.New(db)
+	size := config.Database.PermCacheSize
+	ttl := config.Database.PermCacheTTL
+	if ttl <= 0 || size <= 0 {
+		return perms
+	}
+	if r == nil {
+		return perm.NewCache(perms, size, ttl)
+	}
+	return perm.NewCacheRedis(perms, size, ttl, r)
+}
+
 // provideBatchStore is a Wire provider function that provides a
 // batcher. If the experimental batcher is enabled it is returned.
-func provideBatchStore(db *db.DB, config config.Config) core.Batcher {
+// The batcher is wrapped to evict cached permissions.
+func provideBatchStore(db *db.DB, perms core.PermStore, config config.Config) core.Batcher {
 	if config.Database.LegacyBatch {
-		return batch.New(db)
+		return perm.NewBatcher(batch.New(db), perms)
 	}
-	return batch2.New(db)
+	return perm.NewBatcher(batch2.New(db), perms)
 }
 
 // provideUserStore is a Wire provider function that provides a
@@ -40,7 +40,9 @@ import (
 
 func main() {
 	var envfile string
+	var rotate bool
 	flag.StringVar(&envfile, "env-file", ".env", "Read in a file of environment variables")
+	flag.BoolVar(&rotate, "rotate-secrets", false, "Re-encrypt stored secrets with the database secret and exit")
 	flag.Parse()
 
 	godotenv.Load(envfile)
@@ -61,6 +63,16 @@ func main() {
 		fmt.Println(config.String())
 	}
 
+	// re-encrypt stored secrets with the database secret
+	// after the secret is rotated, and exit.
+	if rotate {
+		if err := rotateSecrets(ctx, config); err != nil {
+			logger := logrus.WithError(err)
+			logger.Fatalln("main: cannot rotate secrets")
+		}
+		return
+	}
+
 	app, err := InitializeApplication(config)
 	if err != nil {
 		logger := logrus.WithError(err)
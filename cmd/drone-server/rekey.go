@@ -0,0 +1,51 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/drone/drone/cmd/drone-server/config"
+	"github.com/drone/drone/store/rekey"
+
+	"github.com/sirupsen/logrus"
+)
+
+// rotateSecrets re-encrypts stored secrets with the database
+// secret. The previous database secrets must be configured so
+// that existing secrets can be decrypted.
+func rotateSecrets(ctx context.Context, config config.Config) error {
+	conn, err := provideDatabase(config)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	enc, err := provideEncrypter(config)
+	if err != nil {
+		return err
+	}
+
+	var columns []rekey.Column
+	columns = append(columns, rekey.Secrets...)
+	if config.Database.EncryptUserTable {
+		columns = append(columns, rekey.Users...)
+	}
+
+	count, err := rekey.Rekey(ctx, conn, enc, columns...)
+	logrus.WithField("count", count).
+		Infoln("main: re-encrypted stored secrets")
+	return err
+}
@@ -17,12 +17,15 @@ import (
 	"github.com/drone/drone/service/hook/parser"
 	"github.com/drone/drone/service/license"
 	"github.com/drone/drone/service/linker"
+	"github.com/drone/drone/service/maintenance"
 	"github.com/drone/drone/service/token"
 	"github.com/drone/drone/service/transfer"
 	"github.com/drone/drone/service/user"
+	"github.com/drone/drone/store/banner"
 	"github.com/drone/drone/store/card"
 	"github.com/drone/drone/store/cron"
-	"github.com/drone/drone/store/perm"
+	maintenance2 "github.com/drone/drone/store/maintenance"
+	"github.com/drone/drone/store/orphan"
 	"github.com/drone/drone/store/secret"
 	"github.com/drone/drone/store/secret/global"
 	"github.com/drone/drone/store/step"
@@ -66,7 +69,10 @@ func InitializeApplication(config2 config.Config) (application, error) {
 	statusService := provideStatusService(client, renewer, config2)
 	stepStore := step.New(db)
 	system := provideSystem(config2)
-	webhookSender := provideWebhookPlugin(config2, system)
+	webhookSender, err := provideWebhookPlugin(config2, system)
+	if err != nil {
+		return application{}, err
+	}
 	coreCanceler := canceler.New(buildStore, corePubsub, repositoryStore, scheduler, stageStore, statusService, stepStore, userStore, webhookSender)
 	fileService := provideContentService(client, renewer)
 	configService := provideConfigPlugin(client, fileService, config2)
@@ -74,7 +80,9 @@ func InitializeApplication(config2 config.Config) (application, error) {
 	convertService := provideConvertPlugin(client, fileService, config2, templateStore)
 	validateService := provideValidatePlugin(config2)
 	triggerer := trigger.New(coreCanceler, configService, convertService, commitService, statusService, buildStore, scheduler, repositoryStore, userStore, validateService, webhookSender)
-	cronScheduler := cron2.New(commitService, cronStore, repositoryStore, userStore, triggerer)
+	maintenanceStore := maintenance2.New(db)
+	maintenanceService := maintenance.New(maintenanceStore)
+	cronScheduler := cron2.New(commitService, cronStore, maintenanceService, repositoryStore, userStore, triggerer)
 	reaper := provideReaper(repositoryStore, buildStore, stageStore, coreCanceler, config2)
 	coreLicense := provideLicense(client, config2)
 	datadog := provideDatadog(userStore, repositoryStore, buildStore, system, coreLicense, config2)
@@ -91,29 +99,32 @@ func InitializeApplication(config2 config.Config) (application, error) {
 	hookService := provideHookService(client, renewer, config2)
 	licenseService := license.NewService(userStore, repositoryStore, buildStore, coreLicense)
 	organizationService := provideOrgService(client, renewer)
-	permStore := perm.New(db)
+	permStore := providePermStore(db, redisDB, config2)
+	bannerStore := banner.New(db)
+	orphanStore := orphan.New(db)
 	repositoryService := provideRepositoryService(client, renewer, config2)
 	session, err := provideSession(userStore, config2)
 	if err != nil {
 		return application{}, err
 	}
-	batcher := provideBatchStore(db, config2)
+	batcher := provideBatchStore(db, permStore, config2)
 	syncer := provideSyncer(repositoryService, repositoryStore, userStore, batcher, config2)
 	transferer := transfer.New(repositoryStore, permStore)
 	userService := user.New(client, renewer)
-	server := api.New(buildStore, commitService, cardStore, cronStore, corePubsub, globalSecretStore, hookService, logStore, coreLicense, licenseService, organizationService, permStore, repositoryStore, repositoryService, scheduler, secretStore, stageStore, stepStore, statusService, session, logStream, syncer, system, templateStore, transferer, triggerer, userStore, userService, webhookSender)
+	server := api.New(bannerStore, buildStore, commitService, cardStore, cronStore, corePubsub, globalSecretStore, hookService, logStore, coreLicense, licenseService, maintenanceService, organizationService, orphanStore, permStore, repositoryStore, repositoryService, scheduler, secretStore, stageStore, stepStore, statusService, session, logStream, syncer, system, templateStore, transferer, triggerer, userStore, userService, webhookSender)
 	admissionService := provideAdmissionPlugin(client, organizationService, userService, config2)
 	hookParser := parser.New(client)
 	coreLinker := linker.New(client)
 	middleware := provideLogin(config2)
 	options := provideServerOptions(config2)
 	webServer := web.New(admissionService, buildStore, client, hookParser, coreLicense, licenseService, coreLinker, middleware, repositoryStore, session, syncer, triggerer, userStore, userService, webhookSender, options, system)
-	mainRpcHandlerV1 := provideRPC(buildManager, config2)
-	mainRpcHandlerV2 := provideRPC2(buildManager, config2)
-	mainHealthzHandler := provideHealthz()
+	mainRpcHandlerV1 := provideRPC(buildManager, maintenanceService, config2)
+	mainRpcHandlerV2 := provideRPC2(buildManager, maintenanceService, config2)
+	mainHealthzHandler := provideHealthz(scheduler)
+	mainReadyzHandler := provideReadyz(db, redisDB, logStore)
 	metricServer := provideMetric(session, config2)
 	mainPprofHandler := providePprof(config2)
-	mux := provideRouter(server, webServer, mainRpcHandlerV1, mainRpcHandlerV2, mainHealthzHandler, metricServer, mainPprofHandler)
+	mux := provideRouter(server, webServer, mainRpcHandlerV1, mainRpcHandlerV2, mainHealthzHandler, mainReadyzHandler, metricServer, mainPprofHandler)
 	serverServer := provideServer(mux, config2)
 	mainApplication := newApplication(cronScheduler, reaper, datadog, runner, serverServer, userStore)
 	return mainApplication, nil
@@ -0,0 +1,83 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// Banner levels.
+const (
+	BannerInfo    = "info"
+	BannerWarning = "warning"
+)
+
+var (
+	errBannerMessageInvalid = errors.New("Invalid Banner Message")
+	errBannerLevelInvalid   = errors.New("Invalid Banner Level")
+	errBannerWindowInvalid  = errors.New("Invalid Banner End Time")
+)
+
+type (
+	// Banner represents an announcement displayed to all
+	// users, such as a scheduled maintenance window.
+	Banner struct {
+		ID          int64  `json:"id"`
+		Message     string `json:"message"`
+		Level       string `json:"level"`
+		Dismissible bool   `json:"dismissible"`
+		Starts      int64  `json:"starts,omitempty"`
+		Ends        int64  `json:"ends,omitempty"`
+		Created     int64  `json:"created"`
+		Updated     int64  `json:"updated"`
+	}
+
+	// BannerStore persists announcement banners.
+	BannerStore interface {
+		// List returns a list of all banners from the datastore.
+		List(context.Context) ([]*Banner, error)
+
+		// ListActive returns a list of banners from the datastore
+		// that are displayed at the given unix timestamp.
+		ListActive(context.Context, int64) ([]*Banner, error)
+
+		// Find returns a banner from the datastore.
+		Find(context.Context, int64) (*Banner, error)
+
+		// Create persists a new banner to the datastore.
+		Create(context.Context, *Banner) error
+
+		// Update persists an updated banner to the datastore.
+		Update(context.Context, *Banner) error
+
+		// Delete deletes a banner from the datastore.
+		Delete(context.Context, *Banner) error
+	}
+)
+
+// Validate validates the required fields and formats.
+func (b *Banner) Validate() error {
+	switch {
+	case b.Message == "":
+		return errBannerMessageInvalid
+	case b.Level != BannerInfo && b.Level != BannerWarning:
+		return errBannerLevelInvalid
+	case b.Ends != 0 && b.Ends <= b.Starts:
+		return errBannerWindowInvalid
+	default:
+		return nil
+	}
+}
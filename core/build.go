@@ -66,12 +66,26 @@ type BuildStore interface {
 	// FindLast returns the last build from the datastore by ref.
 	FindRef(context.Context, int64, string) (*Build, error)
 
+	// FindTag returns the latest successful tag build,
+	// optionally limited to tags created from the named
+	// branch. Tags are ordered by build, not by version.
+	FindTag(context.Context, int64, string) (*Build, error)
+
 	// List returns a list of builds from the datastore by repository id.
 	List(context.Context, int64, int, int) ([]*Build, error)
 
 	// ListRef returns a list of builds from the datastore by ref.
 	ListRef(context.Context, int64, string, int, int) ([]*Build, error)
 
+	// ListSender returns a list of builds from the datastore
+	// by sender login, across all repositories.
+	ListSender(context.Context, string, int, int) ([]*Build, error)
+
+	// ListBefore returns a list of builds from the datastore
+	// with an identifier less than the cursor. It is used for
+	// keyset pagination of large build histories.
+	ListBefore(context.Context, int64, int64, int) ([]*Build, error)
+
 	// LatestBranches returns the latest builds from the
 	// datastore by branch.
 	LatestBranches(context.Context, int64) ([]*Build, error)
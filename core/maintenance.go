@@ -0,0 +1,51 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "context"
+
+// Maintenance represents the system maintenance mode. When
+// enabled, api requests that modify data are rejected and runners
+// do not receive new stages. Webhooks from the scm provider are
+// still accepted, since most providers do not redeliver failed
+// webhooks, and the resulting builds stay pending until
+// maintenance mode is disabled.
+type Maintenance struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+	Updated int64  `json:"updated,omitempty"`
+}
+
+// MaintenanceStore persists the system maintenance mode.
+type MaintenanceStore interface {
+	// Find returns the maintenance mode from the datastore.
+	Find(context.Context) (*Maintenance, error)
+
+	// Update persists the maintenance mode to the datastore.
+	Update(context.Context, *Maintenance) error
+}
+
+// MaintenanceService manages the system maintenance mode.
+type MaintenanceService interface {
+	// Find returns the current maintenance mode.
+	Find(context.Context) (*Maintenance, error)
+
+	// Enable enables maintenance mode with the message
+	// displayed to users.
+	Enable(context.Context, string) error
+
+	// Disable disables maintenance mode.
+	Disable(context.Context) error
+}
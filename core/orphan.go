@@ -0,0 +1,40 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "context"
+
+type (
+	// Orphans provides a count of records that reference a
+	// parent record that no longer exists. For example, the
+	// builds of a repository that has been deleted.
+	Orphans struct {
+		Builds int64 `json:"builds"`
+		Stages int64 `json:"stages"`
+		Steps  int64 `json:"steps"`
+		Logs   int64 `json:"logs"`
+		Cards  int64 `json:"cards"`
+	}
+
+	// OrphanStore finds and removes orphaned records.
+	OrphanStore interface {
+		// Count returns a count of orphaned records.
+		Count(context.Context) (*Orphans, error)
+
+		// Purge deletes orphaned records and returns a count
+		// of the records deleted.
+		Purge(context.Context) (*Orphans, error)
+	}
+)
@@ -121,6 +121,10 @@ type (
 		// stored in the database, including disabled repositories.
 		ListAll(ctx context.Context, limit, offset int) ([]*Repository, error)
 
+		// ListAfter returns a list of all repositories stored in
+		// the database with an identifier greater than the cursor.
+		ListAfter(ctx context.Context, cursor int64, limit int) ([]*Repository, error)
+
 		// Find returns a repository from the datastore.
 		Find(context.Context, int64) (*Repository, error)
 
@@ -32,6 +32,10 @@ const (
 	WebhookActionDeleted  = "deleted"
 	WebhookActionEnabled  = "enabled"
 	WebhookActionDisabled = "disabled"
+
+	// WebhookActionVisibility is sent when the repository
+	// visibility changes.
+	WebhookActionVisibility = "visibility"
 )
 
 type (
@@ -17,15 +17,20 @@ package api
 import (
 	"net/http"
 	"os"
+	"sort"
 
 	"github.com/drone/drone/core"
 	"github.com/drone/drone/handler/api/acl"
 	"github.com/drone/drone/handler/api/auth"
 	"github.com/drone/drone/handler/api/badge"
+	"github.com/drone/drone/handler/api/banners"
 	globalbuilds "github.com/drone/drone/handler/api/builds"
 	"github.com/drone/drone/handler/api/card"
 	"github.com/drone/drone/handler/api/ccmenu"
+	"github.com/drone/drone/handler/api/deprecate"
 	"github.com/drone/drone/handler/api/events"
+	"github.com/drone/drone/handler/api/maintenance"
+	"github.com/drone/drone/handler/api/orphans"
 	"github.com/drone/drone/handler/api/queue"
 	"github.com/drone/drone/handler/api/repos"
 	"github.com/drone/drone/handler/api/repos/builds"
@@ -55,13 +60,14 @@ import (
 var corsOpts = cors.Options{
 	AllowedOrigins:   []string{"*"},
 	AllowedMethods:   []string{"GET", "POST", "PATCH", "PUT", "DELETE", "OPTIONS"},
-	AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-	ExposedHeaders:   []string{"Link"},
+	AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-None-Match", "X-CSRF-Token"},
+	ExposedHeaders:   []string{"Deprecation", "ETag", "Link", "Sunset"},
 	AllowCredentials: true,
 	MaxAge:           300,
 }
 
 func New(
+	banners core.BannerStore,
 	builds core.BuildStore,
 	commits core.CommitService,
 	card core.CardStore,
@@ -72,7 +78,9 @@ func New(
 	logs core.LogStore,
 	license *core.License,
 	licenses core.LicenseService,
+	maintenance core.MaintenanceService,
 	orgs core.OrganizationService,
+	orphans core.OrphanStore,
 	perms core.PermStore,
 	repos core.RepositoryStore,
 	repoz core.RepositoryService,
@@ -93,83 +101,125 @@ func New(
 	webhook core.WebhookSender,
 ) Server {
 	return Server{
-		Builds:     builds,
-		Card:       card,
-		Cron:       cron,
-		Commits:    commits,
-		Events:     events,
-		Globals:    globals,
-		Hooks:      hooks,
-		Logs:       logs,
-		License:    license,
-		Licenses:   licenses,
-		Orgs:       orgs,
-		Perms:      perms,
-		Repos:      repos,
-		Repoz:      repoz,
-		Scheduler:  scheduler,
-		Secrets:    secrets,
-		Stages:     stages,
-		Steps:      steps,
-		Status:     status,
-		Session:    session,
-		Stream:     stream,
-		Syncer:     syncer,
-		System:     system,
-		Template:   template,
-		Transferer: transferer,
-		Triggerer:  triggerer,
-		Users:      users,
-		Userz:      userz,
-		Webhook:    webhook,
+		Banners:     banners,
+		Builds:      builds,
+		Card:        card,
+		Cron:        cron,
+		Commits:     commits,
+		Events:      events,
+		Globals:     globals,
+		Hooks:       hooks,
+		Logs:        logs,
+		License:     license,
+		Licenses:    licenses,
+		Maintenance: maintenance,
+		Orgs:        orgs,
+		Orphans:     orphans,
+		Perms:       perms,
+		Repos:       repos,
+		Repoz:       repoz,
+		Scheduler:   scheduler,
+		Secrets:     secrets,
+		Stages:      stages,
+		Steps:       steps,
+		Status:      status,
+		Session:     session,
+		Stream:      stream,
+		Syncer:      syncer,
+		System:      system,
+		Template:    template,
+		Transferer:  transferer,
+		Triggerer:   triggerer,
+		Users:       users,
+		Userz:       userz,
+		Webhook:     webhook,
 	}
 }
 
 // Server is a http.Handler which exposes drone functionality over HTTP.
 type Server struct {
-	Builds     core.BuildStore
-	Card       core.CardStore
-	Cron       core.CronStore
-	Commits    core.CommitService
-	Events     core.Pubsub
-	Globals    core.GlobalSecretStore
-	Hooks      core.HookService
-	Logs       core.LogStore
-	License    *core.License
-	Licenses   core.LicenseService
-	Orgs       core.OrganizationService
-	Perms      core.PermStore
-	Repos      core.RepositoryStore
-	Repoz      core.RepositoryService
-	Scheduler  core.Scheduler
-	Secrets    core.SecretStore
-	Stages     core.StageStore
-	Steps      core.StepStore
-	Status     core.StatusService
-	Session    core.Session
-	Stream     core.LogStream
-	Syncer     core.Syncer
-	System     *core.System
-	Template   core.TemplateStore
-	Transferer core.Transferer
-	Triggerer  core.Triggerer
-	Users      core.UserStore
-	Userz      core.UserService
-	Webhook    core.WebhookSender
-	Private    bool
+	Banners     core.BannerStore
+	Builds      core.BuildStore
+	Card        core.CardStore
+	Cron        core.CronStore
+	Commits     core.CommitService
+	Events      core.Pubsub
+	Globals     core.GlobalSecretStore
+	Hooks       core.HookService
+	Logs        core.LogStore
+	License     *core.License
+	Licenses    core.LicenseService
+	Maintenance core.MaintenanceService
+	Orgs        core.OrganizationService
+	Orphans     core.OrphanStore
+	Perms       core.PermStore
+	Repos       core.RepositoryStore
+	Repoz       core.RepositoryService
+	Scheduler   core.Scheduler
+	Secrets     core.SecretStore
+	Stages      core.StageStore
+	Steps       core.StepStore
+	Status      core.StatusService
+	Session     core.Session
+	Stream      core.LogStream
+	Syncer      core.Syncer
+	System      *core.System
+	Template    core.TemplateStore
+	Transferer  core.Transferer
+	Triggerer   core.Triggerer
+	Users       core.UserStore
+	Userz       core.UserService
+	Webhook     core.WebhookSender
+	Private     bool
 }
 
-// Handler returns an http.Handler
+// Capabilities returns the optional api features served by
+// this server, so that clients can feature-detect instead of
+// comparing version numbers. Features backed by a store or
+// service are only reported if it is wired in.
+func (s Server) Capabilities() []string {
+	capabilities := []string{
+		"conditional_requests", // ETag and If-None-Match
+		"cursor_pagination",    // ?cursor= on build lists
+		"error_codes",          // machine-readable error codes
+		"field_selection",      // ?fields= on list endpoints
+	}
+	if s.Banners != nil {
+		capabilities = append(capabilities, "banners") // GET /api/banners
+	}
+	if s.Maintenance != nil {
+		capabilities = append(capabilities, "maintenance") // GET /api/system/maintenance
+	}
+	sort.Strings(capabilities)
+	return capabilities
+}
+
+// Handler returns an http.Handler that serves the stable
+// version 1 API, which is mounted at /api and /api/v1.
 func (s Server) Handler() http.Handler {
+	return s.handler(1)
+}
+
+// HandlerV2 returns an http.Handler that serves the version 2
+// API, which is mounted at /api/v2. It is identical to version 1,
+// except that endpoints with a breaking change serve their new
+// response and deprecated aliases are removed.
+func (s Server) HandlerV2() http.Handler {
+	return s.handler(2)
+}
+
+func (s Server) handler(version int) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.NoCache)
+	r.Use(noCache)
 	r.Use(logger.Middleware)
 	r.Use(auth.HandleAuthentication(s.Session))
 
 	cors := cors.New(corsOpts)
 	r.Use(cors.Handler)
 
+	r.Use(maintenance.Reject(s.Maintenance))
+
 	r.Route("/repos", func(r chi.Router) {
 		// temporary workaround to enable private mode
 		// for the drone server.
@@ -188,7 +238,7 @@ func (s Server) Handler() http.Handler {
 			r.Get("/", repos.HandleFind())
 			r.With(
 				acl.CheckAdminAccess(),
-			).Patch("/", repos.HandleUpdate(s.Repos))
+			).Patch("/", repos.HandleUpdate(s.Repos, s.Webhook))
 			r.With(
 				acl.CheckAdminAccess(),
 			).Post("/", repos.HandleEnable(s.Hooks, s.Repos, s.Webhook))
@@ -302,8 +352,18 @@ func (s Server) Handler() http.Handler {
 		})
 	})
 
+	r.Route("/banners", func(r chi.Router) {
+		r.Get("/", banners.HandleActive(s.Banners))
+		r.With(acl.AuthorizeAdmin).Get("/all", banners.HandleList(s.Banners))
+		r.With(acl.AuthorizeAdmin).Post("/", banners.HandleCreate(s.Banners))
+		r.With(acl.AuthorizeAdmin).Get("/{banner}", banners.HandleFind(s.Banners))
+		r.With(acl.AuthorizeAdmin).Patch("/{banner}", banners.HandleUpdate(s.Banners))
+		r.With(acl.AuthorizeAdmin).Delete("/{banner}", banners.HandleDelete(s.Banners))
+	})
+
 	r.Route("/badges/{owner}/{name}", func(r chi.Router) {
 		r.Get("/status.svg", badge.Handler(s.Repos, s.Builds))
+		r.Get("/version.svg", badge.HandleVersion(s.Repos, s.Builds))
 		r.With(
 			acl.InjectRepository(s.Repoz, s.Repos, s.Perms),
 			acl.CheckReadAccess(),
@@ -326,7 +386,11 @@ func (s Server) Handler() http.Handler {
 		r.Post("/repos", user.HandleSync(s.Syncer, s.Repos))
 
 		// TODO(bradrydzewski) finalize the name for this endpoint.
-		r.Get("/builds", user.HandleRecent(s.Repos))
+		if version == 1 {
+			r.With(
+				deprecate.Handler("/api/user/builds/recent"),
+			).Get("/builds", user.HandleRecent(s.Repos))
+		}
 		r.Get("/builds/recent", user.HandleRecent(s.Repos))
 
 		// expose remote endpoints (e.g. to github)
@@ -343,6 +407,7 @@ func (s Server) Handler() http.Handler {
 		r.Post("/{user}/token/rotate", users.HandleTokenRotation(s.Users))
 		r.Delete("/{user}", users.HandleDelete(s.Users, s.Transferer, s.Webhook))
 		r.Get("/{user}/repos", users.HandleRepoList(s.Users, s.Repos))
+		r.Get("/{user}/builds", users.HandleBuildList(s.Users, s.Builds))
 	})
 
 	r.Route("/stream", func(r chi.Router) {
@@ -359,8 +424,14 @@ func (s Server) Handler() http.Handler {
 
 	r.Route("/builds", func(r chi.Router) {
 		r.Use(acl.AuthorizeAdmin)
-		r.Get("/incomplete", globalbuilds.HandleIncomplete(s.Repos))
-		r.Get("/incomplete/v2", globalbuilds.HandleRunningStatus(s.Repos))
+		if version == 1 {
+			r.With(
+				deprecate.Handler("/api/v2/builds/incomplete"),
+			).Get("/incomplete", globalbuilds.HandleIncomplete(s.Repos))
+			r.Get("/incomplete/v2", globalbuilds.HandleRunningStatus(s.Repos))
+		} else {
+			r.Get("/incomplete", globalbuilds.HandleRunningStatus(s.Repos))
+		}
 	})
 
 	r.Route("/secrets", func(r chi.Router) {
@@ -385,6 +456,11 @@ func (s Server) Handler() http.Handler {
 
 	r.Route("/system", func(r chi.Router) {
 		r.Use(acl.AuthorizeAdmin)
+		r.Get("/maintenance", maintenance.HandleFind(s.Maintenance))
+		r.Post("/maintenance", maintenance.HandleEnable(s.Maintenance))
+		r.Delete("/maintenance", maintenance.HandleDisable(s.Maintenance))
+		r.Get("/orphans", orphans.HandleFind(s.Orphans))
+		r.Delete("/orphans", orphans.HandlePurge(s.Orphans))
 		// r.Get("/license", system.HandleLicense())
 		// r.Get("/limits", system.HandleLimits())
 		r.Get("/stats", system.HandleStats(
@@ -399,3 +475,19 @@ func (s Server) Handler() http.Handler {
 
 	return r
 }
+
+// noCache is an http.Handler middleware that sets the http headers
+// that prevent the response from being cached by an upstream proxy.
+// Unlike middleware.NoCache it preserves the conditional request
+// headers, so that handlers can respond with 304 not modified.
+// Handlers that set an ETag replace the Cache-Control header, so
+// that the client keeps the response and revalidates it.
+func noCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 UTC")
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate, private, max-age=0")
+		w.Header().Set("Pragma", "no-cache")
+		w.Header().Set("X-Accel-Expires", "0")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badge
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/drone/drone/core"
+
+	"github.com/go-chi/chi"
+)
+
+// HandleVersion returns an http.HandlerFunc that writes an svg
+// badge with the latest release version to the response. The
+// release version is the name of the tag with the most recent
+// successful build, optionally limited to tags created from the
+// branch given by the ref or branch parameter. Versions are not
+// compared, so a successful rebuild of an older tag is reported
+// as the latest release.
+func HandleVersion(
+	repos core.RepositoryStore,
+	builds core.BuildStore,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := chi.URLParam(r, "owner")
+		name := chi.URLParam(r, "name")
+		branch := strings.TrimPrefix(r.FormValue("ref"), "refs/heads/")
+		if v := r.FormValue("branch"); v != "" {
+			branch = v
+		}
+
+		// an SVG response is always served, even when error, so
+		// we can go ahead and set the content type appropriately.
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Cache-Control", "no-cache, no-store, max-age=0, must-revalidate")
+		w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "image/svg+xml")
+
+		repo, err := repos.FindName(r.Context(), namespace, name)
+		if err != nil {
+			io.WriteString(w, renderBadge("release", "none", "#9f9f9f"))
+			return
+		}
+
+		build, err := builds.FindTag(r.Context(), repo.ID, branch)
+		if err != nil {
+			io.WriteString(w, renderBadge("release", "none", "#9f9f9f"))
+			return
+		}
+
+		version := strings.TrimPrefix(build.Ref, "refs/tags/")
+		io.WriteString(w, renderBadge("release", version, "#007ec6"))
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package badge
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/mock"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHandleVersion(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTag := &core.Build{
+		ID:     5,
+		RepoID: 1,
+		Number: 5,
+		Event:  core.EventTag,
+		Status: core.StatusPassing,
+		Ref:    "refs/tags/v1.2.0",
+	}
+
+	repos := mock.NewMockRepositoryStore(controller)
+	repos.EXPECT().FindName(gomock.Any(), gomock.Any(), mockRepo.Name).Return(mockRepo, nil)
+
+	builds := mock.NewMockBuildStore(controller)
+	builds.EXPECT().FindTag(gomock.Any(), mockRepo.ID, "").Return(mockTag, nil)
+
+	c := new(chi.Context)
+	c.URLParams.Add("owner", "octocat")
+	c.URLParams.Add("name", "hello-world")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleVersion(repos, builds)(w, r)
+	if got, want := w.Code, 200; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+	if got, want := w.Header().Get("Content-Type"), "image/svg+xml"; got != want {
+		t.Errorf("Want Content-Type %q, got %q", want, got)
+	}
+	if got, want := w.Header().Get("Cache-Control"), "no-cache, no-store, max-age=0, must-revalidate"; got != want {
+		t.Errorf("Want Cache-Control %q, got %q", want, got)
+	}
+	if got, want := w.Body.String(), renderBadge("release", "v1.2.0", "#007ec6"); got != want {
+		t.Errorf("Want badge %q, got %q", want, got)
+	}
+}
+
+func TestHandleVersion_Branch(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTag := &core.Build{
+		ID:     5,
+		RepoID: 1,
+		Number: 5,
+		Event:  core.EventTag,
+		Ref:    "refs/tags/v1.1.4",
+		Target: "release-1.1",
+	}
+
+	repos := mock.NewMockRepositoryStore(controller)
+	repos.EXPECT().FindName(gomock.Any(), gomock.Any(), mockRepo.Name).Return(mockRepo, nil).Times(2)
+
+	builds := mock.NewMockBuildStore(controller)
+	builds.EXPECT().FindTag(gomock.Any(), mockRepo.ID, "release-1.1").Return(mockTag, nil).Times(2)
+
+	c := new(chi.Context)
+	c.URLParams.Add("owner", "octocat")
+	c.URLParams.Add("name", "hello-world")
+
+	for _, target := range []string{"/?branch=release-1.1", "/?ref=refs/heads/release-1.1"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", target, nil)
+		r = r.WithContext(
+			context.WithValue(context.Background(), chi.RouteCtxKey, c),
+		)
+
+		HandleVersion(repos, builds)(w, r)
+		if got, want := w.Body.String(), renderBadge("release", "v1.1.4", "#007ec6"); got != want {
+			t.Errorf("Want badge %q, got %q", want, got)
+		}
+	}
+}
+
+func TestHandleVersion_NoTag(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	repos := mock.NewMockRepositoryStore(controller)
+	repos.EXPECT().FindName(gomock.Any(), gomock.Any(), mockRepo.Name).Return(mockRepo, nil)
+
+	builds := mock.NewMockBuildStore(controller)
+	builds.EXPECT().FindTag(gomock.Any(), mockRepo.ID, "").Return(nil, sql.ErrNoRows)
+
+	c := new(chi.Context)
+	c.URLParams.Add("owner", "octocat")
+	c.URLParams.Add("name", "hello-world")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleVersion(repos, builds)(w, r)
+	if got, want := w.Body.String(), renderBadge("release", "none", "#9f9f9f"); got != want {
+		t.Errorf("Want badge %q, got %q", want, got)
+	}
+}
+
+func TestRenderBadge(t *testing.T) {
+	got := renderBadge("release", "<v1>", "#007ec6")
+	if strings.Contains(got, "<v1>") {
+		t.Errorf("Expect badge message is escaped")
+	}
+	if !strings.HasPrefix(got, `<svg xmlns="http://www.w3.org/2000/svg" width="97" height="20">`) {
+		t.Errorf("Unexpected badge width, got %q", got)
+	}
+}
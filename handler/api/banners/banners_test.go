@@ -0,0 +1,228 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package banners
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/mock"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+var dummyBanner = &core.Banner{
+	ID:          1,
+	Message:     "scheduled maintenance on saturday",
+	Level:       core.BannerWarning,
+	Dismissible: true,
+}
+
+func TestHandleActive(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().ListActive(gomock.Any(), gomock.Any()).Return([]*core.Banner{dummyBanner}, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	HandleActive(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got, want := []*core.Banner{}, []*core.Banner{dummyBanner}
+	json.NewDecoder(w.Body).Decode(&got)
+	if diff := cmp.Diff(got, want); len(diff) != 0 {
+		t.Errorf(diff)
+	}
+}
+
+func TestHandleFind(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().Find(gomock.Any(), dummyBanner.ID).Return(dummyBanner, nil)
+
+	c := new(chi.Context)
+	c.URLParams.Add("banner", "1")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleFind(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got, want := new(core.Banner), dummyBanner
+	json.NewDecoder(w.Body).Decode(got)
+	if diff := cmp.Diff(got, want); len(diff) != 0 {
+		t.Errorf(diff)
+	}
+}
+
+func TestHandleFind_NotFound(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().Find(gomock.Any(), dummyBanner.ID).Return(nil, sql.ErrNoRows)
+
+	c := new(chi.Context)
+	c.URLParams.Add("banner", "1")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleFind(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusNotFound; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestHandleCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	in := new(bytes.Buffer)
+	json.NewEncoder(in).Encode(dummyBanner)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", in)
+
+	HandleCreate(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got := new(core.Banner)
+	json.NewDecoder(w.Body).Decode(got)
+	if got, want := got.Message, dummyBanner.Message; got != want {
+		t.Errorf("Want banner message %q, got %q", want, got)
+	}
+	if got, want := got.Level, dummyBanner.Level; got != want {
+		t.Errorf("Want banner level %q, got %q", want, got)
+	}
+	if got.Created == 0 {
+		t.Errorf("Want banner created timestamp")
+	}
+}
+
+func TestHandleCreate_Invalid(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	in := new(bytes.Buffer)
+	json.NewEncoder(in).Encode(&core.Banner{Message: "hello", Level: "critical"})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", in)
+
+	HandleCreate(nil).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusBadRequest; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestHandleUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	before := *dummyBanner
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().Find(gomock.Any(), dummyBanner.ID).Return(&before, nil)
+	banners.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	c := new(chi.Context)
+	c.URLParams.Add("banner", "1")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("PATCH", "/", bytes.NewBufferString(`{"level":"info"}`))
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleUpdate(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got := new(core.Banner)
+	json.NewDecoder(w.Body).Decode(got)
+	if got, want := got.Level, core.BannerInfo; got != want {
+		t.Errorf("Want banner level %q, got %q", want, got)
+	}
+	if got, want := got.Message, dummyBanner.Message; got != want {
+		t.Errorf("Want banner message %q, got %q", want, got)
+	}
+}
+
+func TestHandleDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().Find(gomock.Any(), dummyBanner.ID).Return(dummyBanner, nil)
+	banners.EXPECT().Delete(gomock.Any(), dummyBanner).Return(nil)
+
+	c := new(chi.Context)
+	c.URLParams.Add("banner", "1")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/", nil)
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleDelete(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusNoContent; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestHandleDelete_NotFound(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	banners := mock.NewMockBannerStore(controller)
+	banners.EXPECT().Find(gomock.Any(), dummyBanner.ID).Return(nil, sql.ErrNoRows)
+
+	c := new(chi.Context)
+	c.URLParams.Add("banner", "1")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/", nil)
+	r = r.WithContext(
+		context.WithValue(context.Background(), chi.RouteCtxKey, c),
+	)
+
+	HandleDelete(banners).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusNotFound; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banners
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/render"
+	"github.com/drone/drone/logger"
+)
+
+type bannerInput struct {
+	Message     *string `json:"message"`
+	Level       *string `json:"level"`
+	Dismissible *bool   `json:"dismissible"`
+	Starts      *int64  `json:"starts"`
+	Ends        *int64  `json:"ends"`
+}
+
+// HandleCreate returns an http.HandlerFunc that processes http
+// requests to create a new banner.
+func HandleCreate(banners core.BannerStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in := new(bannerInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequest(w, err)
+			logger.FromRequest(r).WithError(err).
+				Debugln("api: cannot unmarshal request body")
+			return
+		}
+
+		banner := &core.Banner{
+			Level:   core.BannerInfo,
+			Created: time.Now().Unix(),
+			Updated: time.Now().Unix(),
+		}
+		in.apply(banner)
+
+		err = banner.Validate()
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		err = banners.Create(r.Context(), banner)
+		if err != nil {
+			render.InternalError(w, err)
+			logger.FromRequest(r).WithError(err).
+				Debugln("api: cannot create banner")
+			return
+		}
+		render.JSON(w, banner, 200)
+	}
+}
+
+// helper function copies the non-nil input fields to
+// the banner.
+func (in *bannerInput) apply(banner *core.Banner) {
+	if in.Message != nil {
+		banner.Message = *in.Message
+	}
+	if in.Level != nil {
+		banner.Level = *in.Level
+	}
+	if in.Dismissible != nil {
+		banner.Dismissible = *in.Dismissible
+	}
+	if in.Starts != nil {
+		banner.Starts = *in.Starts
+	}
+	if in.Ends != nil {
+		banner.Ends = *in.Ends
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banners
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/render"
+	"github.com/drone/drone/logger"
+
+	"github.com/go-chi/chi"
+)
+
+// HandleUpdate returns an http.HandlerFunc that processes http
+// requests to update a banner.
+func HandleUpdate(banners core.BannerStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(chi.URLParam(r, "banner"), 10, 64)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+		banner, err := banners.Find(r.Context(), id)
+		if err != nil {
+			render.NotFound(w, err)
+			logger.FromRequest(r).WithError(err).
+				WithField("banner", id).
+				Debugln("api: cannot find banner")
+			return
+		}
+
+		in := new(bannerInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequest(w, err)
+			logger.FromRequest(r).WithError(err).
+				Debugln("api: cannot unmarshal request body")
+			return
+		}
+		in.apply(banner)
+		banner.Updated = time.Now().Unix()
+
+		err = banner.Validate()
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		err = banners.Update(r.Context(), banner)
+		if err != nil {
+			render.InternalError(w, err)
+			logger.FromRequest(r).WithError(err).
+				WithField("banner", id).
+				Debugln("api: cannot update banner")
+			return
+		}
+		render.JSON(w, banner, 200)
+	}
+}
@@ -67,7 +67,7 @@ func TestHandleBuilds_Error(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := &errors.Error{}, errors.ErrNotFound
+	got, want := &errors.Error{}, errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -114,7 +114,7 @@ func TestHandleCreate_BadRequest(t *testing.T) {
 	)
 
 	HandleCreate(nil, nil, nil, nil, nil).ServeHTTP(w, r)
-	got, want := &errors.Error{}, &errors.Error{Message: "EOF"}
+	got, want := &errors.Error{}, &errors.Error{Code: errors.CodeBadRequest, Message: "EOF"}
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -160,7 +160,7 @@ func TestHandleCreate_CreateError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.ErrNotFound
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
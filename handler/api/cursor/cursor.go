@@ -0,0 +1,52 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cursor provides opaque cursors for keyset pagination
+// of list endpoints.
+package cursor
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Encode encodes the identifier as an opaque cursor.
+func Encode(id int64) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(id, 10)),
+	)
+}
+
+// Decode decodes the identifier from the opaque cursor.
+func Decode(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(raw), 10, 64)
+}
+
+// Next returns the Link header that points to the next page
+// of results, which starts after the identifier. The query
+// parameters of the request, such as fields, are carried over
+// to the next page.
+func Next(r *http.Request, id int64, limit int) string {
+	next := r.URL.Query()
+	next.Del("page")
+	next.Set("cursor", Encode(id))
+	next.Set("per_page", strconv.Itoa(limit))
+	return fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode())
+}
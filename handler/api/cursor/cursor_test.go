@@ -0,0 +1,31 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package cursor
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	id, err := Decode(Encode(42))
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := id, int64(42); got != want {
+		t.Errorf("Want decoded id %d, got %d", want, got)
+	}
+	if _, err := Decode("!!"); err == nil {
+		t.Errorf("Want error decoding malformed cursor")
+	}
+}
+
+func TestNext(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/repos?page=2&per_page=5&fields=slug", nil)
+	link := `</api/repos?cursor=` + Encode(42) + `&fields=slug&per_page=10>; rel="next"`
+	if got, want := Next(r, 42, 10), link; got != want {
+		t.Errorf("Want Link header %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deprecate
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Handler returns an http.Handler middleware that flags the
+// endpoint as deprecated using the Deprecation header. If the
+// successor is non-empty it is written to the Link header, so
+// that integrators can migrate before the endpoint is removed.
+func Handler(successor string) func(http.Handler) http.Handler {
+	return HandlerSunset(time.Time{}, successor)
+}
+
+// HandlerSunset returns an http.Handler middleware that flags
+// the endpoint as deprecated, like Handler, and writes the date
+// the endpoint will be removed to the Sunset header. A zero
+// sunset time is not written.
+func HandlerSunset(sunset time.Time, successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			if successor != "" {
+				w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
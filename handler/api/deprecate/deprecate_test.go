@@ -0,0 +1,72 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package deprecate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	Handler("/api/user/builds/recent")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	).ServeHTTP(w, r)
+
+	if got, want := w.Code, http.StatusTeapot; got != want {
+		t.Errorf("Want status code %d, got %d", want, got)
+	}
+	if got, want := w.Header().Get("Deprecation"), "true"; got != want {
+		t.Errorf("Want Deprecation header %q, got %q", want, got)
+	}
+	if got, want := w.Header().Get("Link"), `</api/user/builds/recent>; rel="successor-version"`; got != want {
+		t.Errorf("Want Link header %q, got %q", want, got)
+	}
+}
+
+func TestHandler_NoSuccessor(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	Handler("")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	).ServeHTTP(w, r)
+
+	if got, want := w.Header().Get("Deprecation"), "true"; got != want {
+		t.Errorf("Want Deprecation header %q, got %q", want, got)
+	}
+	if got := w.Header().Get("Link"); got != "" {
+		t.Errorf("Want empty Link header, got %q", got)
+	}
+	if got := w.Header().Get("Sunset"); got != "" {
+		t.Errorf("Want empty Sunset header, got %q", got)
+	}
+}
+
+func TestHandlerSunset(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+
+	sunset := time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)
+	HandlerSunset(sunset, "/api/v2/builds/incomplete")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	).ServeHTTP(w, r)
+
+	if got, want := w.Header().Get("Deprecation"), "true"; got != want {
+		t.Errorf("Want Deprecation header %q, got %q", want, got)
+	}
+	if got, want := w.Header().Get("Sunset"), "Wed, 30 Jun 2027 00:00:00 GMT"; got != want {
+		t.Errorf("Want Sunset header %q, got %q", want, got)
+	}
+	if got, want := w.Header().Get("Link"), `</api/v2/builds/incomplete>; rel="successor-version"`; got != want {
+		t.Errorf("Want Link header %q, got %q", want, got)
+	}
+}
@@ -14,22 +14,42 @@
 
 package errors
 
+import "net/http"
+
+// Error codes are stable, machine-readable identifiers for API
+// errors. Clients should match on the code and not the message,
+// which is intended for humans and may change.
+const (
+	CodeBadRequest     = "bad_request"
+	CodeClientError    = "client_error"
+	CodeLicenseLimit   = "license_limit"
+	CodeInvalidToken   = "invalid_token"
+	CodeUnauthorized   = "unauthorized"
+	CodeForbidden      = "forbidden"
+	CodeNotFound       = "not_found"
+	CodeConflict       = "conflict"
+	CodeInternal       = "internal"
+	CodeNotImplemented = "not_implemented"
+	CodeUnavailable    = "unavailable"
+)
+
 var (
 	// ErrInvalidToken is returned when the api request token is invalid.
-	ErrInvalidToken = New("Invalid or missing token")
+	ErrInvalidToken = NewCode(CodeInvalidToken, "Invalid or missing token")
 
 	// ErrUnauthorized is returned when the user is not authorized.
-	ErrUnauthorized = New("Unauthorized")
+	ErrUnauthorized = NewCode(CodeUnauthorized, "Unauthorized")
 
 	// ErrForbidden is returned when user access is forbidden.
-	ErrForbidden = New("Forbidden")
+	ErrForbidden = NewCode(CodeForbidden, "Forbidden")
 
 	// ErrNotFound is returned when a resource is not found.
-	ErrNotFound = New("Not Found")
+	ErrNotFound = NewCode(CodeNotFound, "Not Found")
 )
 
 // Error represents a json-encoded API error.
 type Error struct {
+	Code    string `json:"code,omitempty"`
 	Message string `json:"message"`
 }
 
@@ -41,3 +61,63 @@ func (e *Error) Error() string {
 func New(text string) error {
 	return &Error{Message: text}
 }
+
+// NewCode returns a new error message with the error code.
+func NewCode(code, text string) error {
+	return &Error{Code: code, Message: text}
+}
+
+// StatusCode returns the default error code for the http
+// status code. Client errors without a specific code map to
+// client_error, and never to internal.
+func StatusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusPaymentRequired:
+		return CodeLicenseLimit
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusNotImplemented:
+		return CodeNotImplemented
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status >= 400 && status < 500 {
+		return CodeClientError
+	}
+	return CodeInternal
+}
+
+// Status returns the http status code for the error code, or
+// zero if the error code is unknown.
+func Status(code string) int {
+	switch code {
+	case CodeBadRequest:
+		return http.StatusBadRequest
+	case CodeLicenseLimit:
+		return http.StatusPaymentRequired
+	case CodeInvalidToken, CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeInternal:
+		return http.StatusInternalServerError
+	case CodeNotImplemented:
+		return http.StatusNotImplemented
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return 0
+	}
+}
@@ -12,3 +12,37 @@ func TestError(t *testing.T) {
 		t.Errorf("Want error string %q, got %q", got, want)
 	}
 }
+
+func TestStatus(t *testing.T) {
+	codes := []string{
+		CodeBadRequest,
+		CodeLicenseLimit,
+		CodeUnauthorized,
+		CodeForbidden,
+		CodeNotFound,
+		CodeConflict,
+		CodeInternal,
+		CodeNotImplemented,
+		CodeUnavailable,
+	}
+	for _, code := range codes {
+		if got := StatusCode(Status(code)); got != code {
+			t.Errorf("Want error code %s to round trip, got %s", code, got)
+		}
+	}
+	if got, want := Status(CodeInvalidToken), 401; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+	if got, want := Status("pc_load_letter"), 0; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+}
+
+func TestStatusCode_ClientError(t *testing.T) {
+	if got, want := StatusCode(418), CodeClientError; got != want {
+		t.Errorf("Want error code %s, got %s", want, got)
+	}
+	if got, want := StatusCode(502), CodeInternal; got != want {
+		t.Errorf("Want error code %s, got %s", want, got)
+	}
+}
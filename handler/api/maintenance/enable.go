@@ -0,0 +1,53 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/render"
+	"github.com/drone/drone/logger"
+)
+
+type maintenanceInput struct {
+	Message string `json:"message"`
+}
+
+// HandleEnable returns an http.HandlerFunc that processes
+// an http.Request to enable maintenance mode.
+func HandleEnable(maintenance core.MaintenanceService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in := new(maintenanceInput)
+		if r.ContentLength != 0 {
+			err := json.NewDecoder(r.Body).Decode(in)
+			if err != nil {
+				render.BadRequest(w, err)
+				logger.FromRequest(r).WithError(err).
+					Debugln("api: cannot unmarshal request body")
+				return
+			}
+		}
+		err := maintenance.Enable(r.Context(), in.Message)
+		if err != nil {
+			render.InternalError(w, err)
+			logger.FromRequest(r).WithError(err).
+				Errorln("api: cannot enable maintenance mode")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/errors"
+	"github.com/drone/drone/handler/api/render"
+	"github.com/drone/drone/handler/api/request"
+	"github.com/drone/drone/logger"
+)
+
+// Reject returns an http.Handler middleware that rejects requests
+// that modify data while maintenance mode is enabled. Read requests
+// are always allowed, and administrators are exempt so that they
+// can disable maintenance mode.
+func Reject(maintenance core.MaintenanceService) func(http.Handler) http.Handler {
+	return reject(maintenance, func(r *http.Request) bool {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return false
+		}
+		user, ok := request.UserFrom(r.Context())
+		return !ok || !user.Admin
+	})
+}
+
+// RejectQueue returns an http.Handler middleware that rejects
+// runner requests for pending stages while maintenance mode is
+// enabled. Other runner requests are allowed, so that running
+// builds can complete. Requests without a valid rpc secret are
+// passed through, so that the rpc server rejects them as
+// unauthorized before maintenance mode is checked.
+func RejectQueue(maintenance core.MaintenanceService, secret string) func(http.Handler) http.Handler {
+	return reject(maintenance, func(r *http.Request) bool {
+		if secret == "" || r.Header.Get("X-Drone-Token") != secret {
+			return false
+		}
+		switch {
+		case r.URL.Path == "/rpc/v1/request":
+			return true
+		case r.URL.Path == "/rpc/v2/stage":
+			return r.Method == http.MethodPost
+		}
+		return false
+	})
+}
+
+// helper function returns an http.Handler middleware that rejects
+// the matching requests while maintenance mode is enabled. If the
+// maintenance service is nil, no requests are rejected.
+func reject(maintenance core.MaintenanceService, match func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if maintenance == nil || !match(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			mode, err := maintenance.Find(r.Context())
+			if err != nil {
+				render.InternalError(w, err)
+				logger.FromRequest(r).WithError(err).
+					Errorln("api: cannot find maintenance mode")
+				return
+			}
+			if mode.Enabled {
+				render.ErrorCode(w, errors.New(mode.Message), http.StatusServiceUnavailable)
+				logger.FromRequest(r).
+					Debugln("api: request rejected in maintenance mode")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/errors"
+	"github.com/drone/drone/handler/api/request"
+	"github.com/drone/drone/mock"
+	"github.com/drone/drone/service/maintenance"
+
+	"github.com/golang/mock/gomock"
+)
+
+var noop = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusTeapot)
+})
+
+// helper function returns a maintenance service backed by a
+// mock store, with maintenance mode initially disabled.
+func newService(controller *gomock.Controller) core.MaintenanceService {
+	store := mock.NewMockMaintenanceStore(controller)
+	store.EXPECT().Find(gomock.Any()).Return(&core.Maintenance{}, nil).AnyTimes()
+	store.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return maintenance.New(store)
+}
+
+func TestReject(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := newService(controller)
+	service.Enable(context.Background(), "database upgrade in progress")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/repos/octocat/hello-world/builds", nil)
+	r = r.WithContext(
+		request.WithUser(r.Context(), &core.User{Login: "octocat"}),
+	)
+
+	Reject(service)(noop).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusServiceUnavailable; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got, want := new(errors.Error), "database upgrade in progress"
+	json.NewDecoder(w.Body).Decode(got)
+	if got.Message != want {
+		t.Errorf("Want error message %q, got %q", want, got.Message)
+	}
+}
+
+func TestReject_Read(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := newService(controller)
+	service.Enable(context.Background(), "")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/repos/octocat/hello-world/builds", nil)
+
+	Reject(service)(noop).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusTeapot; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestReject_Admin(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := newService(controller)
+	service.Enable(context.Background(), "")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("DELETE", "/api/system/maintenance", nil)
+	r = r.WithContext(
+		request.WithUser(r.Context(), &core.User{Login: "octocat", Admin: true}),
+	)
+
+	Reject(service)(noop).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusTeapot; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestReject_Disabled(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := newService(controller)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/user/repos", nil)
+
+	Reject(service)(noop).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusTeapot; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestReject_NilService(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/api/user/repos", nil)
+
+	Reject(nil)(noop).ServeHTTP(w, r)
+	if got, want := w.Code, http.StatusTeapot; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestRejectQueue(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := newService(controller)
+	service.Enable(context.Background(), "")
+
+	tests := []struct {
+		method string
+		path   string
+		token  string
+		code   int
+	}{
+		{"POST", "/rpc/v1/request", "correct-horse", http.StatusServiceUnavailable},
+		{"POST", "/rpc/v2/stage", "correct-horse", http.StatusServiceUnavailable},
+		{"POST", "/rpc/v1/write", "correct-horse", http.StatusTeapot},
+		{"POST", "/rpc/v2/stage/1", "correct-horse", http.StatusTeapot},
+		{"GET", "/rpc/v2/stage/1", "correct-horse", http.StatusTeapot},
+		// unauthenticated requests are passed to the rpc server,
+		// which responds with 401 unauthorized.
+		{"POST", "/rpc/v1/request", "", http.StatusTeapot},
+		{"POST", "/rpc/v2/stage", "battery-staple", http.StatusTeapot},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.method, test.path, nil)
+		r.Header.Set("X-Drone-Token", test.token)
+
+		RejectQueue(service, "correct-horse")(noop).ServeHTTP(w, r)
+		if got, want := w.Code, test.code; got != want {
+			t.Errorf("Want response code %d for %s %s, got %d", want, test.method, test.path, got)
+		}
+	}
+}
+
+func TestHandleEnable(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := newService(controller)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"message":"back soon"}`))
+
+	HandleEnable(service)(w, r)
+	if got, want := w.Code, http.StatusNoContent; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	mode, _ := service.Find(context.Background())
+	if !mode.Enabled {
+		t.Errorf("Expect maintenance mode enabled")
+	}
+	if got, want := mode.Message, "back soon"; got != want {
+		t.Errorf("Want message %q, got %q", want, got)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("DELETE", "/", nil)
+	HandleDisable(service)(w, r)
+	if got, want := w.Code, http.StatusNoContent; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	mode, _ = service.Find(context.Background())
+	if mode.Enabled {
+		t.Errorf("Expect maintenance mode disabled")
+	}
+}
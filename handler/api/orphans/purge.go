@@ -0,0 +1,46 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orphans
+
+import (
+	"net/http"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/render"
+	"github.com/drone/drone/logger"
+)
+
+// HandlePurge returns an http.HandlerFunc that deletes
+// orphaned records and writes the json-encoded count of
+// deleted records to the response body.
+func HandlePurge(orphans core.OrphanStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		out, err := orphans.Purge(r.Context())
+		if err != nil {
+			render.InternalError(w, err)
+			logger.FromRequest(r).WithError(err).
+				Errorln("api: cannot purge orphaned records")
+			return
+		}
+		logger.FromRequest(r).
+			WithField("builds", out.Builds).
+			WithField("stages", out.Stages).
+			WithField("steps", out.Steps).
+			WithField("logs", out.Logs).
+			WithField("cards", out.Cards).
+			Infoln("api: orphaned records purged")
+		render.JSON(w, out, 200)
+	}
+}
@@ -15,11 +15,15 @@
 package render
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/drone/drone/handler/api/errors"
 )
@@ -35,24 +39,33 @@ func init() {
 
 var (
 	// ErrInvalidToken is returned when the api request token is invalid.
-	ErrInvalidToken = errors.New("Invalid or missing token")
+	ErrInvalidToken = errors.NewCode(errors.CodeInvalidToken, "Invalid or missing token")
 
 	// ErrUnauthorized is returned when the user is not authorized.
-	ErrUnauthorized = errors.New("Unauthorized")
+	ErrUnauthorized = errors.NewCode(errors.CodeUnauthorized, "Unauthorized")
 
 	// ErrForbidden is returned when user access is forbidden.
-	ErrForbidden = errors.New("Forbidden")
+	ErrForbidden = errors.NewCode(errors.CodeForbidden, "Forbidden")
 
 	// ErrNotFound is returned when a resource is not found.
-	ErrNotFound = errors.New("Not Found")
+	ErrNotFound = errors.NewCode(errors.CodeNotFound, "Not Found")
 
 	// ErrNotImplemented is returned when an endpoint is not implemented.
-	ErrNotImplemented = errors.New("Not Implemented")
+	ErrNotImplemented = errors.NewCode(errors.CodeNotImplemented, "Not Implemented")
 )
 
 // ErrorCode writes the json-encoded error message to the response.
+// The error code is derived from the http status code, unless the
+// error is an api error with a more specific code for the same
+// status (e.g. invalid_token for 401).
 func ErrorCode(w http.ResponseWriter, err error, status int) {
-	JSON(w, &errors.Error{Message: err.Error()}, status)
+	out := &errors.Error{Message: err.Error()}
+	if e, ok := err.(*errors.Error); ok && e.Code != "" && errors.Status(e.Code) == status {
+		out.Code = e.Code
+	} else {
+		out.Code = errors.StatusCode(status)
+	}
+	JSON(w, out, status)
 }
 
 // InternalError writes the json-encoded error message to the response
@@ -120,3 +133,124 @@ func JSON(w http.ResponseWriter, v interface{}, status int) {
 	}
 	enc.Encode(v)
 }
+
+// JSONFields writes the json-encoded value to the response, limited
+// to the comma-separated list of fields. Nested fields are selected
+// using dot notation (e.g. build.number), and apply to each item
+// if the parent field is a list. If the list of fields is empty the
+// full value is written.
+func JSONFields(w http.ResponseWriter, v interface{}, fields string, status int) {
+	if fields == "" {
+		JSON(w, v, status)
+		return
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		InternalError(w, err)
+		return
+	}
+	// numbers are decoded as json.Number to preserve integer
+	// values larger than a float64 can represent exactly.
+	var out interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		InternalError(w, err)
+		return
+	}
+	keep := selection{}
+	for _, field := range strings.Split(fields, ",") {
+		keep.add(strings.Split(strings.TrimSpace(field), "."))
+	}
+	JSON(w, keep.filter(out), status)
+}
+
+// selection is a tree of selected json fields. A field with a
+// nil selection is selected in full.
+type selection map[string]selection
+
+// helper function adds the field path to the selection.
+func (s selection) add(path []string) {
+	sub, ok := s[path[0]]
+	switch {
+	case ok && sub == nil:
+		// the field is already selected in full.
+	case len(path) == 1:
+		s[path[0]] = nil
+	default:
+		if sub == nil {
+			sub = selection{}
+			s[path[0]] = sub
+		}
+		sub.add(path[1:])
+	}
+}
+
+// helper function removes the fields from the json value that
+// are not selected. Lists are filtered item by item.
+func (s selection) filter(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			sub, ok := s[key]
+			switch {
+			case !ok:
+				delete(v, key)
+			case sub != nil:
+				v[key] = sub.filter(val)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = s.filter(item)
+		}
+	}
+	return v
+}
+
+// matchETag reports whether any entity tag in the If-None-Match
+// header values matches the etag. The comparison is weak, as
+// required by RFC 7232, so a W/ prefix is ignored.
+func matchETag(values []string, etag string) bool {
+	for _, value := range values {
+		for _, match := range strings.Split(value, ",") {
+			match = strings.TrimSpace(match)
+			if match == "*" || strings.TrimPrefix(match, "W/") == etag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JSONETag writes the json-encoded value to the response with an
+// ETag header computed from the response body. If the ETag matches
+// the If-None-Match request header, the body is omitted and a 304
+// not modified status code is written instead. The response may be
+// stored by the client, but must be revalidated before each use.
+func JSONETag(w http.ResponseWriter, r *http.Request, v interface{}, status int) {
+	var data []byte
+	var err error
+	if indent {
+		data, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		InternalError(w, err)
+		return
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache, private")
+	if matchETag(r.Header["If-None-Match"], etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+	w.Write([]byte{'\n'})
+}
@@ -47,6 +47,46 @@ func TestWriteErrorCode(t *testing.T) {
 	}
 }
 
+func TestWriteErrorCode_Default(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	NotFound(w, errors.New("pc load letter"))
+
+	errjson := &errors.Error{}
+	json.NewDecoder(w.Body).Decode(errjson)
+	if got, want := errjson.Code, errors.CodeNotFound; got != want {
+		t.Errorf("Want error code %s, got %s", want, got)
+	}
+}
+
+func TestWriteErrorCode_FromError(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	Unauthorized(w, errors.ErrInvalidToken)
+
+	errjson := &errors.Error{}
+	json.NewDecoder(w.Body).Decode(errjson)
+	if got, want := errjson.Code, errors.CodeInvalidToken; got != want {
+		t.Errorf("Want error code %s, got %s", want, got)
+	}
+}
+
+func TestWriteErrorCode_StatusMismatch(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	InternalError(w, errors.ErrNotFound)
+
+	if got, want := w.Code, 500; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	errjson := &errors.Error{}
+	json.NewDecoder(w.Body).Decode(errjson)
+	if got, want := errjson.Code, errors.CodeInternal; got != want {
+		t.Errorf("Want error code %s, got %s", want, got)
+	}
+}
+
 func TestWriteNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 
@@ -205,3 +245,132 @@ func TestWriteJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteJSONFields(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	}
+	// list of objects
+	{
+		w := httptest.NewRecorder()
+		JSONFields(w, []*item{{ID: 1, Slug: "octocat/hello-world", Name: "hello-world"}}, "id, slug", http.StatusOK)
+		if got, want := w.Body.String(), "[{\"id\":1,\"slug\":\"octocat/hello-world\"}]\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+	}
+	// single object
+	{
+		w := httptest.NewRecorder()
+		JSONFields(w, &item{ID: 1, Slug: "octocat/hello-world"}, "name", http.StatusOK)
+		if got, want := w.Body.String(), "{\"name\":\"\"}\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+	}
+	// without fields
+	{
+		w := httptest.NewRecorder()
+		JSONFields(w, &item{ID: 1}, "", http.StatusOK)
+		if got, want := w.Body.String(), "{\"id\":1,\"slug\":\"\",\"name\":\"\"}\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+	}
+	// large integers are not converted to floating point
+	{
+		w := httptest.NewRecorder()
+		JSONFields(w, &item{ID: 9007199254740993}, "id", http.StatusOK)
+		if got, want := w.Body.String(), "{\"id\":9007199254740993}\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+	}
+}
+
+func TestWriteJSONFields_Nested(t *testing.T) {
+	type build struct {
+		Number int    `json:"number"`
+		Status string `json:"status"`
+	}
+	type repo struct {
+		ID     int      `json:"id"`
+		Slug   string   `json:"slug"`
+		Build  *build   `json:"build"`
+		Stages []*build `json:"stages"`
+	}
+	v := &repo{
+		ID:     1,
+		Slug:   "octocat/hello-world",
+		Build:  &build{Number: 42, Status: "success"},
+		Stages: []*build{{Number: 1, Status: "success"}},
+	}
+	// nested object and list fields
+	{
+		w := httptest.NewRecorder()
+		JSONFields(w, v, "slug,build.status,stages.number", http.StatusOK)
+		if got, want := w.Body.String(), "{\"build\":{\"status\":\"success\"},\"slug\":\"octocat/hello-world\",\"stages\":[{\"number\":1}]}\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+	}
+	// parent field selected in full
+	{
+		w := httptest.NewRecorder()
+		JSONFields(w, v, "build.status,build", http.StatusOK)
+		if got, want := w.Body.String(), "{\"build\":{\"number\":42,\"status\":\"success\"}}\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+	}
+}
+
+func TestWriteJSONETag(t *testing.T) {
+	v := map[string]string{"hello": "world"}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	JSONETag(w, r, v, http.StatusOK)
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Errorf("Want status code %d, got %d", want, got)
+	}
+	if got, want := w.Body.String(), "{\"hello\":\"world\"}\n"; got != want {
+		t.Errorf("Want JSON body %q, got %q", want, got)
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Errorf("Want ETag header")
+	}
+	if got, want := w.Header().Get("Cache-Control"), "no-cache, private"; got != want {
+		t.Errorf("Want Cache-Control %q, got %q", want, got)
+	}
+
+	// the etag matches, expect not modified
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", etag)
+	JSONETag(w, r, v, http.StatusOK)
+	if got, want := w.Code, http.StatusNotModified; got != want {
+		t.Errorf("Want status code %d, got %d", want, got)
+	}
+	if got := w.Body.Len(); got != 0 {
+		t.Errorf("Want empty body, got %d bytes", got)
+	}
+
+	// a weak etag in a list of etags, expect not modified
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", `"c3ab8ff1", W/`+etag)
+	JSONETag(w, r, v, http.StatusOK)
+	if got, want := w.Code, http.StatusNotModified; got != want {
+		t.Errorf("Want status code %d, got %d", want, got)
+	}
+
+	// the value changes, expect a new etag
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("If-None-Match", etag)
+	JSONETag(w, r, map[string]string{"hello": "gopher"}, http.StatusOK)
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Errorf("Want status code %d, got %d", want, got)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Errorf("Want different ETag for modified value")
+	}
+}
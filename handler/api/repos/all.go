@@ -19,17 +19,21 @@ import (
 	"strconv"
 
 	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/cursor"
 	"github.com/drone/drone/handler/api/render"
 	"github.com/drone/drone/logger"
 )
 
 // HandleAll returns an http.HandlerFunc that processes http
-// requests to list all repositories in the database.
+// requests to list all repositories in the database. The list is
+// paginated by page number or by an opaque cursor returned in the
+// Link header.
 func HandleAll(repos core.RepositoryStore) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
 			page    = r.FormValue("page")
 			perPage = r.FormValue("per_page")
+			token   = r.FormValue("cursor")
 		)
 		offset, _ := strconv.Atoi(page)
 		limit, _ := strconv.Atoi(perPage)
@@ -42,14 +46,33 @@ func HandleAll(repos core.RepositoryStore) http.HandlerFunc {
 		default:
 			offset = (offset - 1) * limit
 		}
-		repo, err := repos.ListAll(r.Context(), limit, offset)
+		var after int64
+		if token != "" {
+			var err error
+			after, err = cursor.Decode(token)
+			if err != nil {
+				render.BadRequestf(w, "Invalid or malformed cursor")
+				return
+			}
+		}
+
+		var repo []*core.Repository
+		var err error
+		if token != "" {
+			repo, err = repos.ListAfter(r.Context(), after, limit)
+		} else {
+			repo, err = repos.ListAll(r.Context(), limit, offset)
+		}
 		if err != nil {
 			render.InternalError(w, err)
 			logger.FromRequest(r).
 				WithError(err).
 				Debugln("api: cannot list repositories")
-		} else {
-			render.JSON(w, repo, 200)
+			return
+		}
+		if len(repo) == limit {
+			w.Header().Set("Link", cursor.Next(r, repo[len(repo)-1].ID, limit))
 		}
+		render.JSONFields(w, repo, r.FormValue("fields"), 200)
 	}
 }
@@ -0,0 +1,75 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package repos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/cursor"
+	"github.com/drone/drone/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHandleAll(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	repos := mock.NewMockRepositoryStore(controller)
+	repos.EXPECT().ListAll(gomock.Any(), 2, 2).Return(mockRepos, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/repos?page=2&per_page=2", nil)
+
+	HandleAll(repos)(w, r)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	link := `</api/repos?cursor=` + cursor.Encode(1) + `&per_page=2>; rel="next"`
+	if got, want := w.Header().Get("Link"), link; got != want {
+		t.Errorf("Want Link header %q, got %q", want, got)
+	}
+
+	got, want := []*core.Repository{}, mockRepos
+	json.NewDecoder(w.Body).Decode(&got)
+	if diff := cmp.Diff(got, want); len(diff) != 0 {
+		t.Errorf(diff)
+	}
+}
+
+func TestHandleAll_Cursor(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	repos := mock.NewMockRepositoryStore(controller)
+	repos.EXPECT().ListAfter(gomock.Any(), int64(1), 25).Return(mockRepos, nil)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/repos?cursor="+cursor.Encode(1), nil)
+
+	HandleAll(repos)(w, r)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+	if got := w.Header().Get("Link"); got != "" {
+		t.Errorf("Want no Link header on the last page, got %q", got)
+	}
+}
+
+func TestHandleAll_CursorInvalid(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/repos?cursor=!!", nil)
+
+	HandleAll(nil)(w, r)
+	if got, want := w.Code, http.StatusBadRequest; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
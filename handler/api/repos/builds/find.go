@@ -56,7 +56,7 @@ func HandleFind(
 			render.InternalError(w, err)
 			return
 		}
-		render.JSON(w, &buildWithStages{build, stages}, 200)
+		render.JSONETag(w, r, &buildWithStages{build, stages}, 200)
 	}
 }
 
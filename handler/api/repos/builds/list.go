@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	"github.com/drone/drone/core"
+	"github.com/drone/drone/handler/api/cursor"
 	"github.com/drone/drone/handler/api/render"
 	"github.com/drone/drone/logger"
 
@@ -27,7 +28,9 @@ import (
 )
 
 // HandleList returns an http.HandlerFunc that writes a json-encoded
-// list of build history to the response body.
+// list of build history to the response body. The list is paginated
+// by page number or, for large build histories, by an opaque cursor
+// returned in the Link header.
 func HandleList(
 	repos core.RepositoryStore,
 	builds core.BuildStore,
@@ -40,6 +43,7 @@ func HandleList(
 			tag       = r.FormValue("tag")
 			page      = r.FormValue("page")
 			perPage   = r.FormValue("per_page")
+			token     = r.FormValue("cursor")
 		)
 		offset, _ := strconv.Atoi(page)
 		limit, _ := strconv.Atoi(perPage)
@@ -52,6 +56,21 @@ func HandleList(
 		default:
 			offset = (offset - 1) * limit
 		}
+		var before int64
+		if token != "" {
+			// the cursor is only supported for the unfiltered
+			// build history, which is ordered by build id.
+			if branch != "" || tag != "" {
+				render.BadRequestf(w, "Cursor is not supported with the branch or tag filter")
+				return
+			}
+			var err error
+			before, err = cursor.Decode(token)
+			if err != nil {
+				render.BadRequestf(w, "Invalid or malformed cursor")
+				return
+			}
+		}
 		repo, err := repos.FindName(r.Context(), namespace, name)
 		if err != nil {
 			render.NotFound(w, err)
@@ -70,6 +89,8 @@ func HandleList(
 		} else if tag != "" {
 			ref := fmt.Sprintf("refs/tags/%s", tag)
 			results, err = builds.ListRef(r.Context(), repo.ID, ref, limit, offset)
+		} else if token != "" {
+			results, err = builds.ListBefore(r.Context(), repo.ID, before, limit)
 		} else {
 			results, err = builds.List(r.Context(), repo.ID, limit, offset)
 		}
@@ -82,7 +103,10 @@ func HandleList(
 				WithField("name", name).
 				Debugln("api: cannot list builds")
 		} else {
-			render.JSON(w, results, 200)
+			if branch == "" && tag == "" && len(results) == limit {
+				w.Header().Set("Link", cursor.Next(r, results[len(results)-1].ID, limit))
+			}
+			render.JSONFields(w, results, r.FormValue("fields"), 200)
 		}
 	}
 }
@@ -128,6 +128,7 @@ func TestPromote_InvalidBuildNumber(t *testing.T) {
 	}
 
 	got, want := new(errors.Error), &errors.Error{
+		Code:    errors.CodeBadRequest,
 		Message: `strconv.ParseInt: parsing "XLII": invalid syntax`,
 	}
 	json.NewDecoder(w.Body).Decode(got)
@@ -225,7 +226,7 @@ func TestPromote_MissingTargetError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), &errors.Error{Message: "Missing target environment"}
+	got, want := new(errors.Error), &errors.Error{Code: errors.CodeBadRequest, Message: "Missing target environment"}
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -261,7 +262,7 @@ func TestPromote_TriggerError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.ErrNotFound
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
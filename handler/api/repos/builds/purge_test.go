@@ -101,6 +101,7 @@ func TestPurge_BadRequest(t *testing.T) {
 	}
 
 	got, want := new(errors.Error), &errors.Error{
+		Code:    errors.CodeBadRequest,
 		Message: `strconv.ParseInt: parsing "XLII": invalid syntax`,
 	}
 	json.NewDecoder(w.Body).Decode(got)
@@ -136,7 +137,7 @@ func TestPurge_InternalError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.ErrNotFound
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
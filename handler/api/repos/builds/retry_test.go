@@ -123,6 +123,7 @@ func TestRetry_InvalidBuildNumber(t *testing.T) {
 	}
 
 	got, want := new(errors.Error), &errors.Error{
+		Code:    errors.CodeBadRequest,
 		Message: `strconv.ParseInt: parsing "XLII": invalid syntax`,
 	}
 	json.NewDecoder(w.Body).Decode(got)
@@ -223,7 +224,7 @@ func TestRetry_TriggerError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.ErrNotFound
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
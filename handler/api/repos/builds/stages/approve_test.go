@@ -130,7 +130,7 @@ func TestApprove_InvalidStatus(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New(`Cannot approve a Pipeline with Status "pending"`)
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, `Cannot approve a Pipeline with Status "pending"`)
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -158,7 +158,7 @@ func TestApprove_InvalidBuildNumber(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Invalid build number")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, "Invalid build number")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -186,7 +186,7 @@ func TestApprove_InvalidStageNumber(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Invalid stage number")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, "Invalid stage number")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -236,7 +236,7 @@ func TestApprove_StageNotFound(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Stage not found")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeNotFound, "Stage not found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -278,7 +278,7 @@ func TestApprove_BuildNotFound(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Build not found")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeNotFound, "Build not found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -317,7 +317,7 @@ func TestApprove_RepoNotFound(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Repository not found")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeNotFound, "Repository not found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -375,7 +375,7 @@ func TestApprove_CannotSaveStage(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("There was a problem approving the Pipeline")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "There was a problem approving the Pipeline")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -436,7 +436,7 @@ func TestApprove_CannotEnqueue(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("There was a problem scheduling the Pipeline")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "There was a problem scheduling the Pipeline")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -41,7 +41,7 @@ func TestDecline_InvalidBuildNumber(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Invalid build number")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, "Invalid build number")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -69,7 +69,7 @@ func TestDecline_InvalidStageNumber(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Invalid stage number")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, "Invalid stage number")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -108,7 +108,7 @@ func TestDecline_RepoNotFound(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Repository not found")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeNotFound, "Repository not found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -150,7 +150,7 @@ func TestDecline_BuildNotFound(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Build not found")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeNotFound, "Build not found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -200,7 +200,7 @@ func TestDecline_StageNotFound(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("Stage not found")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeNotFound, "Stage not found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -255,7 +255,7 @@ func TestDecline_InvalidStatus(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New(`Cannot decline build with status "pending"`)
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, `Cannot decline build with status "pending"`)
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
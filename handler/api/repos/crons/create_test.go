@@ -90,7 +90,7 @@ func TestHandleCreate_ValidationError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := &errors.Error{}, &errors.Error{Message: "Invalid Cronjob Name"}
+	got, want := &errors.Error{}, &errors.Error{Code: errors.CodeBadRequest, Message: "Invalid Cronjob Name"}
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -122,7 +122,7 @@ func TestHandleCreate_BadExpression(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := &errors.Error{}, &errors.Error{Message: "Invalid Cronjob Expression"}
+	got, want := &errors.Error{}, &errors.Error{Code: errors.CodeBadRequest, Message: "Invalid Cronjob Expression"}
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -151,7 +151,7 @@ func TestHandleCreate_BadRequest(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := &errors.Error{}, &errors.Error{Message: "EOF"}
+	got, want := &errors.Error{}, &errors.Error{Code: errors.CodeBadRequest, Message: "EOF"}
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -216,7 +216,7 @@ func TestHandleCreate_CreateError(t *testing.T) {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.ErrNotFound
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -29,6 +29,6 @@ func HandleFind() http.HandlerFunc {
 		repo, _ := request.RepoFrom(ctx)
 		perm, _ := request.PermFrom(ctx)
 		repo.Perms = perm
-		render.JSON(w, repo, 200)
+		render.JSONETag(w, r, repo, 200)
 	}
 }
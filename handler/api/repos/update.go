@@ -45,7 +45,7 @@ type (
 
 // HandleUpdate returns an http.HandlerFunc that processes http
 // requests to update the repository details.
-func HandleUpdate(repos core.RepositoryStore) http.HandlerFunc {
+func HandleUpdate(repos core.RepositoryStore, sender core.WebhookSender) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
 			owner = chi.URLParam(r, "owner")
@@ -75,8 +75,21 @@ func HandleUpdate(repos core.RepositoryStore) http.HandlerFunc {
 			return
 		}
 
+		visibility := repo.Visibility
 		if in.Visibility != nil {
-			repo.Visibility = *in.Visibility
+			switch *in.Visibility {
+			case core.VisibilityPublic,
+				core.VisibilityPrivate,
+				core.VisibilityInternal:
+				repo.Visibility = *in.Visibility
+			default:
+				render.BadRequestf(w, "Invalid visibility %q", *in.Visibility)
+				logger.FromRequest(r).
+					WithField("repository", slug).
+					WithField("visibility", *in.Visibility).
+					Debugln("api: invalid repository visibility")
+				return
+			}
 		}
 		if in.Config != nil {
 			repo.Config = *in.Config
@@ -138,6 +151,33 @@ func HandleUpdate(repos core.RepositoryStore) http.HandlerFunc {
 			return
 		}
 
+		// a change in visibility can expose the repository,
+		// its builds and its logs to a wider audience, and is
+		// therefore logged and sent as a dedicated event.
+		if repo.Visibility != visibility {
+			log := logger.FromRequest(r).
+				WithField("repository", slug).
+				WithField("visibility.old", visibility).
+				WithField("visibility.new", repo.Visibility)
+			if user != nil {
+				log = log.WithField("user", user.Login)
+			}
+			log.Infoln("api: repository visibility changed")
+
+			err = sender.Send(r.Context(), &core.WebhookData{
+				Event:  core.WebhookEventRepo,
+				Action: core.WebhookActionVisibility,
+				User:   user,
+				Repo:   repo,
+			})
+			if err != nil {
+				logger.FromRequest(r).
+					WithError(err).
+					WithField("repository", slug).
+					Warnln("api: cannot send webhook")
+			}
+		}
+
 		render.JSON(w, repo, 200)
 	}
 }
@@ -50,10 +50,23 @@ func TestUpdate(t *testing.T) {
 		return nil
 	}
 
+	checkWebhook := func(_ context.Context, hook *core.WebhookData) error {
+		if got, want := hook.Event, core.WebhookEventRepo; got != want {
+			t.Errorf("Want webhook event %s, got %s", want, got)
+		}
+		if got, want := hook.Action, core.WebhookActionVisibility; got != want {
+			t.Errorf("Want webhook action %s, got %s", want, got)
+		}
+		return nil
+	}
+
 	repos := mock.NewMockRepositoryStore(controller)
 	repos.EXPECT().FindName(gomock.Any(), "octocat", "hello-world").Return(repo, nil)
 	repos.EXPECT().Update(gomock.Any(), repo).Return(nil).Do(checkUpdate)
 
+	webhook := mock.NewMockWebhookSender(controller)
+	webhook.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil).Do(checkWebhook)
+
 	c := new(chi.Context)
 	c.URLParams.Add("owner", "octocat")
 	c.URLParams.Add("name", "hello-world")
@@ -66,7 +79,7 @@ func TestUpdate(t *testing.T) {
 		context.WithValue(r.Context(), chi.RouteCtxKey, c),
 	)
 
-	HandleUpdate(repos)(w, r)
+	HandleUpdate(repos, webhook)(w, r)
 	if got, want := w.Code, 200; want != got {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
@@ -110,7 +123,7 @@ func TestUpdate_RepoNotFound(t *testing.T) {
 		context.WithValue(r.Context(), chi.RouteCtxKey, c),
 	)
 
-	HandleUpdate(repos)(w, r)
+	HandleUpdate(repos, nil)(w, r)
 	if got, want := w.Code, 404; want != got {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
@@ -156,12 +169,12 @@ func TestUpdate_InvalidInput(t *testing.T) {
 		context.WithValue(r.Context(), chi.RouteCtxKey, c),
 	)
 
-	HandleUpdate(repos)(w, r)
+	HandleUpdate(repos, nil)(w, r)
 	if got, want := w.Code, 400; want != got {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.New("EOF")
+	got, want := new(errors.Error), errors.NewCode(errors.CodeBadRequest, "EOF")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -209,12 +222,12 @@ func TestUpdate_UpdateFailed(t *testing.T) {
 		context.WithValue(r.Context(), chi.RouteCtxKey, c),
 	)
 
-	HandleUpdate(repos)(w, r)
+	HandleUpdate(repos, nil)(w, r)
 	if got, want := w.Code, 500; want != got {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
 
-	got, want := new(errors.Error), errors.ErrNotFound
+	got, want := new(errors.Error), errors.NewCode(errors.CodeInternal, "Not Found")
 	json.NewDecoder(w.Body).Decode(got)
 	if diff := cmp.Diff(got, want); len(diff) != 0 {
 		t.Errorf(diff)
@@ -269,7 +282,7 @@ func TestUpdateAutoCancelRunning(t *testing.T) {
 		context.WithValue(r.Context(), chi.RouteCtxKey, c),
 	)
 
-	HandleUpdate(repos)(w, r)
+	HandleUpdate(repos, nil)(w, r)
 	if got, want := w.Code, 200; want != got {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
@@ -293,3 +306,40 @@ func TestUpdateAutoCancelRunning(t *testing.T) {
 		t.Errorf(diff)
 	}
 }
+
+// this test verifies that a 400 bad request error is
+// returned from the http.Handler if the visibility is
+// not a known value.
+func TestUpdate_InvalidVisibility(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	repo := &core.Repository{
+		ID:         1,
+		Namespace:  "octocat",
+		Name:       "hello-world",
+		Slug:       "octocat/hello-world",
+		Visibility: core.VisibilityPrivate,
+	}
+
+	repos := mock.NewMockRepositoryStore(controller)
+	repos.EXPECT().FindName(gomock.Any(), "octocat", "hello-world").Return(repo, nil)
+
+	c := new(chi.Context)
+	c.URLParams.Add("owner", "octocat")
+	c.URLParams.Add("name", "hello-world")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"visibility":"everyone"}`))
+	r = r.WithContext(
+		context.WithValue(r.Context(), chi.RouteCtxKey, c),
+	)
+
+	HandleUpdate(repos, nil)(w, r)
+	if got, want := w.Code, 400; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+	if got, want := repo.Visibility, core.VisibilityPrivate; got != want {
+		t.Errorf("Want visibility unchanged %s, got %s", want, got)
+	}
+}
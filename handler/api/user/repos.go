@@ -41,7 +41,7 @@ func HandleRepos(repos core.RepositoryStore) http.HandlerFunc {
 			logger.FromRequest(r).WithError(err).
 				Debugln("api: cannot list repositories")
 		} else {
-			render.JSON(w, list, 200)
+			render.JSONFields(w, list, r.FormValue("fields"), 200)
 		}
 	}
 }
@@ -15,29 +15,77 @@
 package health
 
 import (
-	"io"
+	"context"
+	"encoding/json"
 	"net/http"
+	"time"
+
+	"github.com/drone/drone/logger"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 )
 
 // New returns a new health check router.
-func New() http.Handler {
+func New(checks map[string]Checker) http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.NoCache)
+	r.Handle("/", Handler(checks))
+	return r
+}
+
+// NewReady returns a new readiness check router.
+func NewReady(checks map[string]Checker) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.NoCache)
-	r.Handle("/", Handler())
+	r.Handle("/", HandleReady(checks))
 	return r
 }
 
+// Checker checks the health of a system dependency and
+// returns an error if the dependency is unavailable.
+type Checker func(context.Context) error
+
 // Handler creates an http.HandlerFunc that performs system
 // healthchecks and returns 500 if the system is in an unhealthy state.
-func Handler() http.HandlerFunc {
+// The status of each check is written to the response body.
+func Handler(checks map[string]Checker) http.HandlerFunc {
+	return handleChecks(checks, http.StatusInternalServerError)
+}
+
+// HandleReady creates an http.HandlerFunc that checks the system
+// dependencies and returns 503 if any dependency is unavailable.
+// The status of each dependency is written to the response body.
+func HandleReady(checks map[string]Checker) http.HandlerFunc {
+	return handleChecks(checks, http.StatusServiceUnavailable)
+}
+
+func handleChecks(checks map[string]Checker, failure int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(200)
-		w.Header().Set("Content-Type", "text/plain")
-		io.WriteString(w, "OK")
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		status := http.StatusOK
+		results := map[string]string{}
+		for name, check := range checks {
+			if err := check(ctx); err != nil {
+				// the endpoints are not authenticated, so the
+				// error is logged and not written to the response,
+				// since it may include hostnames or credentials.
+				status = failure
+				results[name] = "unavailable"
+				logger.FromRequest(r).
+					WithError(err).
+					WithField("check", name).
+					Warnln("health: check failed")
+			} else {
+				results[name] = "ok"
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(results)
 	}
 }
-
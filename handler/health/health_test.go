@@ -5,6 +5,9 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"testing"
 )
@@ -13,9 +16,72 @@ func TestHandleHealthz(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest("GET", "/healthz", nil)
 
-	Handler().ServeHTTP(w, r)
+	Handler(map[string]Checker{
+		"scheduler": func(context.Context) error { return nil },
+	}).ServeHTTP(w, r)
 
 	if got, want := w.Code, 200; want != got {
 		t.Errorf("Want response code %d, got %d", want, got)
 	}
+
+	got := map[string]string{}
+	json.NewDecoder(w.Body).Decode(&got)
+	if got["scheduler"] != "ok" {
+		t.Errorf("Want scheduler status ok, got %q", got["scheduler"])
+	}
+}
+
+func TestHandleHealthz_Unhealthy(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/healthz", nil)
+
+	Handler(map[string]Checker{
+		"scheduler": func(context.Context) error { return errors.New("queue stalled") },
+	}).ServeHTTP(w, r)
+
+	if got, want := w.Code, 500; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestHandleReady(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/readyz", nil)
+
+	HandleReady(map[string]Checker{
+		"database": func(context.Context) error { return nil },
+	}).ServeHTTP(w, r)
+
+	if got, want := w.Code, 200; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got := map[string]string{}
+	json.NewDecoder(w.Body).Decode(&got)
+	if got["database"] != "ok" {
+		t.Errorf("Want database status ok, got %q", got["database"])
+	}
+}
+
+func TestHandleReady_Unavailable(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/readyz", nil)
+
+	HandleReady(map[string]Checker{
+		"database": func(context.Context) error { return nil },
+		"redis":    func(context.Context) error { return errors.New("connection refused") },
+	}).ServeHTTP(w, r)
+
+	if got, want := w.Code, 503; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got := map[string]string{}
+	json.NewDecoder(w.Body).Decode(&got)
+	if got["database"] != "ok" {
+		t.Errorf("Want database status ok, got %q", got["database"])
+	}
+	if got["redis"] != "unavailable" {
+		t.Errorf("Want redis status unavailable, got %q", got["redis"])
+	}
 }
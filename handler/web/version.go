@@ -21,16 +21,25 @@ import (
 )
 
 // HandleVersion creates an http.HandlerFunc that returns the
-// version number and build details.
-func HandleVersion(w http.ResponseWriter, r *http.Request) {
-	v := struct {
-		Source  string `json:"source,omitempty"`
-		Version string `json:"version,omitempty"`
-		Commit  string `json:"commit,omitempty"`
-	}{
-		Source:  version.GitRepository,
-		Commit:  version.GitCommit,
-		Version: version.Version.String(),
+// version number, build details and the optional api features
+// supported by the server, so that clients can feature-detect
+// instead of comparing version numbers.
+func HandleVersion(capabilities []string) http.HandlerFunc {
+	if capabilities == nil {
+		capabilities = []string{}
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		v := struct {
+			Source       string   `json:"source,omitempty"`
+			Version      string   `json:"version,omitempty"`
+			Commit       string   `json:"commit,omitempty"`
+			Capabilities []string `json:"capabilities"`
+		}{
+			Source:       version.GitRepository,
+			Commit:       version.GitCommit,
+			Version:      version.Version.String(),
+			Capabilities: capabilities,
+		}
+		writeJSON(w, &v, 200)
 	}
-	writeJSON(w, &v, 200)
 }
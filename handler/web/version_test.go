@@ -4,6 +4,33 @@
 
 package web
 
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHandleVersion_Capabilities(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/version", nil)
+
+	capabilities := []string{"banners", "error_codes"}
+	HandleVersion(capabilities)(w, r)
+	if got, want := w.Code, 200; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	got := struct {
+		Capabilities []string `json:"capabilities"`
+	}{}
+	json.NewDecoder(w.Body).Decode(&got)
+	if diff := cmp.Diff(got.Capabilities, capabilities); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
 // func TestHandleVersion(t *testing.T) {
 // 	controller := gomock.NewController(t)
 // 	defer controller.Finish()
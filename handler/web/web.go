@@ -89,6 +89,10 @@ type Server struct {
 	Webhook   core.WebhookSender
 	Options   secure.Options
 	Host      string
+
+	// Capabilities lists the optional api features served
+	// by the api server, reported by the version endpoint.
+	Capabilities []string
 }
 
 // Handler returns an http.Handler
@@ -109,7 +113,7 @@ func (s Server) Handler() http.Handler {
 	r.Get("/link/{namespace}/{name}/tree/*", link.HandleTree(s.Linker))
 	r.Get("/link/{namespace}/{name}/src/*", link.HandleTree(s.Linker))
 	r.Get("/link/{namespace}/{name}/commit/{commit}", link.HandleCommit(s.Linker))
-	r.Get("/version", HandleVersion)
+	r.Get("/version", HandleVersion(s.Capabilities))
 	r.Get("/varz", HandleVarz(s.Client, s.License))
 
 	r.Handle("/login",
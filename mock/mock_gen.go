@@ -811,6 +811,21 @@ func (mr *MockBuildStoreMockRecorder) FindNumber(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNumber", reflect.TypeOf((*MockBuildStore)(nil).FindNumber), arg0, arg1, arg2)
 }
 
+// FindTag mocks base method.
+func (m *MockBuildStore) FindTag(arg0 context.Context, arg1 int64, arg2 string) (*core.Build, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*core.Build)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTag indicates an expected call of FindTag.
+func (mr *MockBuildStoreMockRecorder) FindTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTag", reflect.TypeOf((*MockBuildStore)(nil).FindTag), arg0, arg1, arg2)
+}
+
 // FindRef mocks base method.
 func (m *MockBuildStore) FindRef(arg0 context.Context, arg1 int64, arg2 string) (*core.Build, error) {
 	m.ctrl.T.Helper()
@@ -901,6 +916,36 @@ func (mr *MockBuildStoreMockRecorder) ListRef(arg0, arg1, arg2, arg3, arg4 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRef", reflect.TypeOf((*MockBuildStore)(nil).ListRef), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ListBefore mocks base method.
+func (m *MockBuildStore) ListBefore(arg0 context.Context, arg1, arg2 int64, arg3 int) ([]*core.Build, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBefore", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*core.Build)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBefore indicates an expected call of ListBefore.
+func (mr *MockBuildStoreMockRecorder) ListBefore(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBefore", reflect.TypeOf((*MockBuildStore)(nil).ListBefore), arg0, arg1, arg2, arg3)
+}
+
+// ListSender mocks base method.
+func (m *MockBuildStore) ListSender(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*core.Build, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*core.Build)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSender indicates an expected call of ListSender.
+func (mr *MockBuildStoreMockRecorder) ListSender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSender", reflect.TypeOf((*MockBuildStore)(nil).ListSender), arg0, arg1, arg2, arg3)
+}
+
 // Pending mocks base method.
 func (m *MockBuildStore) Pending(arg0 context.Context) ([]*core.Build, error) {
 	m.ctrl.T.Helper()
@@ -1857,6 +1902,21 @@ func (mr *MockRepositoryStoreMockRecorder) List(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepositoryStore)(nil).List), arg0, arg1)
 }
 
+// ListAfter mocks base method.
+func (m *MockRepositoryStore) ListAfter(arg0 context.Context, arg1 int64, arg2 int) ([]*core.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*core.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockRepositoryStoreMockRecorder) ListAfter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockRepositoryStore)(nil).ListAfter), arg0, arg1, arg2)
+}
+
 // ListAll mocks base method.
 func (m *MockRepositoryStore) ListAll(arg0 context.Context, arg1, arg2 int) ([]*core.Repository, error) {
 	m.ctrl.T.Helper()
@@ -2974,3 +3034,218 @@ func (mr *MockCardStoreMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCardStore)(nil).Update), arg0, arg1, arg2)
 }
+
+// MockBannerStore is a mock of BannerStore interface.
+type MockBannerStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBannerStoreMockRecorder
+}
+
+// MockBannerStoreMockRecorder is the mock recorder for MockBannerStore.
+type MockBannerStoreMockRecorder struct {
+	mock *MockBannerStore
+}
+
+// NewMockBannerStore creates a new mock instance.
+func NewMockBannerStore(ctrl *gomock.Controller) *MockBannerStore {
+	mock := &MockBannerStore{ctrl: ctrl}
+	mock.recorder = &MockBannerStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBannerStore) EXPECT() *MockBannerStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBannerStore) Create(arg0 context.Context, arg1 *core.Banner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBannerStoreMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBannerStore)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockBannerStore) Delete(arg0 context.Context, arg1 *core.Banner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBannerStoreMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBannerStore)(nil).Delete), arg0, arg1)
+}
+
+// Find mocks base method.
+func (m *MockBannerStore) Find(arg0 context.Context, arg1 int64) (*core.Banner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0, arg1)
+	ret0, _ := ret[0].(*core.Banner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockBannerStoreMockRecorder) Find(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockBannerStore)(nil).Find), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockBannerStore) List(arg0 context.Context) ([]*core.Banner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*core.Banner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBannerStoreMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBannerStore)(nil).List), arg0)
+}
+
+// ListActive mocks base method.
+func (m *MockBannerStore) ListActive(arg0 context.Context, arg1 int64) ([]*core.Banner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", arg0, arg1)
+	ret0, _ := ret[0].([]*core.Banner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockBannerStoreMockRecorder) ListActive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockBannerStore)(nil).ListActive), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockBannerStore) Update(arg0 context.Context, arg1 *core.Banner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBannerStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBannerStore)(nil).Update), arg0, arg1)
+}
+
+// MockOrphanStore is a mock of OrphanStore interface.
+type MockOrphanStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrphanStoreMockRecorder
+}
+
+// MockOrphanStoreMockRecorder is the mock recorder for MockOrphanStore.
+type MockOrphanStoreMockRecorder struct {
+	mock *MockOrphanStore
+}
+
+// NewMockOrphanStore creates a new mock instance.
+func NewMockOrphanStore(ctrl *gomock.Controller) *MockOrphanStore {
+	mock := &MockOrphanStore{ctrl: ctrl}
+	mock.recorder = &MockOrphanStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrphanStore) EXPECT() *MockOrphanStoreMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockOrphanStore) Count(arg0 context.Context) (*core.Orphans, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0)
+	ret0, _ := ret[0].(*core.Orphans)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockOrphanStoreMockRecorder) Count(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockOrphanStore)(nil).Count), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockOrphanStore) Purge(arg0 context.Context) (*core.Orphans, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0)
+	ret0, _ := ret[0].(*core.Orphans)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockOrphanStoreMockRecorder) Purge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockOrphanStore)(nil).Purge), arg0)
+}
+
+// MockMaintenanceStore is a mock of MaintenanceStore interface.
+type MockMaintenanceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceStoreMockRecorder
+}
+
+// MockMaintenanceStoreMockRecorder is the mock recorder for MockMaintenanceStore.
+type MockMaintenanceStoreMockRecorder struct {
+	mock *MockMaintenanceStore
+}
+
+// NewMockMaintenanceStore creates a new mock instance.
+func NewMockMaintenanceStore(ctrl *gomock.Controller) *MockMaintenanceStore {
+	mock := &MockMaintenanceStore{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceStore) EXPECT() *MockMaintenanceStoreMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockMaintenanceStore) Find(arg0 context.Context) (*core.Maintenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0)
+	ret0, _ := ret[0].(*core.Maintenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockMaintenanceStoreMockRecorder) Find(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMaintenanceStore)(nil).Find), arg0)
+}
+
+// Update mocks base method.
+func (m *MockMaintenanceStore) Update(arg0 context.Context, arg1 *core.Maintenance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMaintenanceStoreMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMaintenanceStore)(nil).Update), arg0, arg1)
+}
@@ -14,12 +14,19 @@
 
 package webhook
 
-import "github.com/drone/drone/core"
+import (
+	"time"
+
+	"github.com/drone/drone/core"
+)
 
 // Config provides the webhook configuration.
 type Config struct {
 	Events   []string
 	Endpoint []string
 	Secret   string
+	Headers  map[string]string
+	Format   string
+	Timeout  time.Duration
 	System   *core.System
 }
@@ -0,0 +1,97 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/drone/drone/core"
+
+	"github.com/dchest/uniuri"
+)
+
+// Payload formats.
+const (
+	FormatNative      = "native"
+	FormatSlack       = "slack"
+	FormatCloudEvents = "cloudevents"
+)
+
+// encoder encodes the webhook payload and returns the
+// encoded body and content type.
+type encoder func(*payload) ([]byte, string, error)
+
+// encoders maps the payload formats to their encoders.
+var encoders = map[string]encoder{
+	"":                encodeNative,
+	FormatNative:      encodeNative,
+	FormatSlack:       encodeSlack,
+	FormatCloudEvents: encodeCloudEvents,
+}
+
+// helper function encodes the payload in the native format.
+func encodeNative(in *payload) ([]byte, string, error) {
+	data, err := json.Marshal(in)
+	return data, "application/json", err
+}
+
+// helper function encodes the payload as a slack-compatible
+// message that can be posted to an incoming webhook.
+func encodeSlack(in *payload) ([]byte, string, error) {
+	data, err := json.Marshal(map[string]string{
+		"text": summary(in),
+	})
+	return data, "application/json", err
+}
+
+// helper function encodes the payload as a cloudevents
+// structured mode event.
+// https://github.com/cloudevents/spec/blob/v1.0/json-format.md
+func encodeCloudEvents(in *payload) ([]byte, string, error) {
+	typ := "io.drone." + in.Event
+	if in.Action != "" {
+		typ = typ + "." + in.Action
+	}
+	var source string
+	if in.System != nil {
+		source = in.System.Link
+	}
+	if source == "" {
+		source = "drone"
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              uniuri.NewLen(32),
+		"type":            typ,
+		"source":          source,
+		"time":            time.Now().UTC().Format(time.RFC3339),
+		"datacontenttype": "application/json",
+		"data":            in,
+	})
+	return data, "application/cloudevents+json", err
+}
+
+// helper function returns a human-readable summary of
+// the webhook event.
+func summary(in *payload) string {
+	switch {
+	case in.Event == core.WebhookEventBuild && in.Repo != nil && in.Build != nil:
+		text := fmt.Sprintf("%s build #%d %s", in.Repo.Slug, in.Build.Number, in.Build.Status)
+		if in.System != nil && in.System.Link != "" {
+			text = fmt.Sprintf("%s %s/%s/%d", text, in.System.Link, in.Repo.Slug, in.Build.Number)
+		}
+		return text
+	case in.Event == core.WebhookEventRepo && in.Repo != nil:
+		return fmt.Sprintf("%s repository %s", in.Repo.Slug, in.Action)
+	case in.Event == core.WebhookEventUser && in.User != nil:
+		return fmt.Sprintf("user %s %s", in.User.Login, in.Action)
+	default:
+		return fmt.Sprintf("%s %s", in.Event, in.Action)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/drone/drone/core"
+)
+
+var dummyPayload = &payload{
+	WebhookData: &core.WebhookData{
+		Event:  core.WebhookEventBuild,
+		Action: core.WebhookActionUpdated,
+		Repo:   &core.Repository{Slug: "octocat/hello-world"},
+		Build:  &core.Build{Number: 42, Status: core.StatusPassing},
+	},
+	System: &core.System{Link: "https://drone.company.com"},
+}
+
+func TestEncodeSlack(t *testing.T) {
+	data, contentType, err := encodeSlack(dummyPayload)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := contentType, "application/json"; got != want {
+		t.Errorf("Want content type %q, got %q", want, got)
+	}
+	out := map[string]string{}
+	json.Unmarshal(data, &out)
+	if got, want := out["text"], "octocat/hello-world build #42 success https://drone.company.com/octocat/hello-world/42"; got != want {
+		t.Errorf("Want text %q, got %q", want, got)
+	}
+}
+
+func TestEncodeCloudEvents(t *testing.T) {
+	data, contentType, err := encodeCloudEvents(dummyPayload)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := contentType, "application/cloudevents+json"; got != want {
+		t.Errorf("Want content type %q, got %q", want, got)
+	}
+	out := map[string]interface{}{}
+	json.Unmarshal(data, &out)
+	if got, want := out["specversion"], "1.0"; got != want {
+		t.Errorf("Want specversion %q, got %q", want, got)
+	}
+	if got, want := out["type"], "io.drone.build.updated"; got != want {
+		t.Errorf("Want type %q, got %q", want, got)
+	}
+	if got, want := out["source"], "https://drone.company.com"; got != want {
+		t.Errorf("Want source %q, got %q", want, got)
+	}
+	if out["id"] == "" {
+		t.Errorf("Want event id")
+	}
+	if _, ok := out["data"].(map[string]interface{}); !ok {
+		t.Errorf("Want event data")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		in   *payload
+		want string
+	}{
+		{
+			in: &payload{WebhookData: &core.WebhookData{
+				Event:  core.WebhookEventRepo,
+				Action: core.WebhookActionEnabled,
+				Repo:   &core.Repository{Slug: "octocat/hello-world"},
+			}},
+			want: "octocat/hello-world repository enabled",
+		},
+		{
+			in: &payload{WebhookData: &core.WebhookData{
+				Event:  core.WebhookEventUser,
+				Action: core.WebhookActionCreated,
+				User:   &core.User{Login: "octocat"},
+			}},
+			want: "user octocat created",
+		},
+		{
+			in: &payload{WebhookData: &core.WebhookData{
+				Event:  core.WebhookEventBuild,
+				Action: core.WebhookActionCreated,
+			}},
+			want: "build created",
+		},
+	}
+	for _, test := range tests {
+		if got := summary(test.in); got != test.want {
+			t.Errorf("Want summary %q, got %q", test.want, got)
+		}
+	}
+}
+
+func TestWebhook_UnknownFormat(t *testing.T) {
+	_, err := New(Config{
+		Endpoint: []string{"https://company.com/hooks"},
+		Format:   "xml",
+	})
+	if err == nil {
+		t.Errorf("Expect error for unknown payload format")
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
-	"encoding/json"
+	"fmt"
 	"net/http"
 	"path/filepath"
 	"time"
@@ -32,14 +32,39 @@ var signer = httpsignatures.NewSigner(
 	headers...,
 )
 
-// New returns a new Webhook sender.
-func New(config Config) core.WebhookSender {
+// reserved http headers are set by the sender and cannot be
+// overridden with custom headers.
+var reserved = []string{
+	"Content-Type",
+	"Date",
+	"Digest",
+	"Signature",
+	"X-Drone-Event",
+}
+
+// New returns a new Webhook sender. An error is returned if the
+// payload format is unknown, or if a custom header overrides a
+// reserved header.
+func New(config Config) (core.WebhookSender, error) {
+	if _, ok := encoders[config.Format]; !ok {
+		return nil, fmt.Errorf("webhook: unknown payload format %q", config.Format)
+	}
+	for key := range config.Headers {
+		for _, name := range reserved {
+			if http.CanonicalHeaderKey(key) == name {
+				return nil, fmt.Errorf("webhook: cannot override reserved header %s", name)
+			}
+		}
+	}
 	return &sender{
 		Events:    config.Events,
 		Endpoints: config.Endpoint,
 		Secret:    config.Secret,
+		Headers:   config.Headers,
+		Format:    config.Format,
+		Timeout:   config.Timeout,
 		System:    config.System,
-	}
+	}, nil
 }
 
 type payload struct {
@@ -52,6 +77,9 @@ type sender struct {
 	Events    []string
 	Endpoints []string
 	Secret    string
+	Headers   map[string]string
+	Format    string
+	Timeout   time.Duration
 	System    *core.System
 }
 
@@ -64,13 +92,17 @@ func (s *sender) Send(ctx context.Context, in *core.WebhookData) error {
 	if s.match(in.Event, in.Action) == false {
 		return nil
 	}
-	wrapper := payload{
+	encode := encoders[s.Format]
+	wrapper := &payload{
 		WebhookData: in,
 		System:      s.System,
 	}
-	data, _ := json.Marshal(wrapper)
+	data, contentType, err := encode(wrapper)
+	if err != nil {
+		return err
+	}
 	for _, endpoint := range s.Endpoints {
-		err := s.send(endpoint, s.Secret, in.Event, data)
+		err := s.send(endpoint, s.Secret, in.Event, contentType, data)
 		if err != nil {
 			return err
 		}
@@ -78,9 +110,9 @@ func (s *sender) Send(ctx context.Context, in *core.WebhookData) error {
 	return nil
 }
 
-func (s *sender) send(endpoint, secret, event string, data []byte) error {
+func (s *sender) send(endpoint, secret, event, contentType string, data []byte) error {
 	ctx := context.Background()
-	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout())
 	defer cancel()
 
 	buf := bytes.NewBuffer(data)
@@ -91,9 +123,15 @@ func (s *sender) send(endpoint, secret, event string, data []byte) error {
 
 	req = req.WithContext(ctx)
 	req.Header.Add("X-Drone-Event", event)
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("Digest", "SHA-256="+digest(data))
 	req.Header.Add("Date", time.Now().UTC().Format(http.TimeFormat))
+	// custom headers are used to authenticate with receivers
+	// that require more than the signature, for example a
+	// bearer token in the Authorization header.
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
 	err = signer.SignRequest("hmac-key", s.Secret, req)
 	if err != nil {
 		return err
@@ -124,6 +162,15 @@ func (s *sender) match(event, action string) bool {
 	return false
 }
 
+// timeout returns the delivery timeout, which defaults to one
+// minute if not configured.
+func (s *sender) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return time.Minute
+}
+
 func (s *sender) client() *http.Client {
 	if s.Client == nil {
 		return http.DefaultClient
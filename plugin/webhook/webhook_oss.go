@@ -23,8 +23,8 @@ import (
 )
 
 // New returns a no-op Webhook sender.
-func New(Config) core.WebhookSender {
-	return new(noop)
+func New(Config) (core.WebhookSender, error) {
+	return new(noop), nil
 }
 
 type noop struct{}
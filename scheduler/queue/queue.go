@@ -16,7 +16,9 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/drone/drone/core"
@@ -25,10 +27,18 @@ import (
 	"github.com/drone/drone-go/drone"
 )
 
+// errStalled is returned by Ping when the scheduling loop has
+// not completed an iteration within the expected time.
+var errStalled = errors.New("scheduler: queue loop stalled")
+
 type queue struct {
 	sync.Mutex
 	globMx redisdb.LockErr
 
+	// beat is the unix time the scheduling loop last
+	// made progress, accessed atomically.
+	beat int64
+
 	ready    chan struct{}
 	paused   bool
 	interval time.Duration
@@ -47,11 +57,25 @@ func newQueue(ctx context.Context, store core.StageStore) *queue {
 		workers:  map[*worker]struct{}{},
 		interval: time.Minute,
 		ctx:      ctx,
+		beat:     time.Now().Unix(),
 	}
 	go q.start()
 	return q
 }
 
+// Ping returns an error if the scheduling loop has not made
+// progress in the last five intervals. The loop records a beat
+// when it wakes, once it holds the global lock, and after each
+// attempt to hand a stage to a runner, so the longest expected
+// gap between beats is a single interval.
+func (q *queue) Ping(ctx context.Context) error {
+	last := time.Unix(atomic.LoadInt64(&q.beat), 0)
+	if time.Since(last) > 5*q.interval {
+		return errStalled
+	}
+	return nil
+}
+
 func (q *queue) Schedule(ctx context.Context, stage *core.Stage) error {
 	select {
 	case q.ready <- struct{}{}:
@@ -125,6 +149,7 @@ func (q *queue) signal(ctx context.Context) error {
 		return err
 	}
 	defer q.globMx.UnlockContext(ctx)
+	q.heartbeat()
 
 	q.Lock()
 	count := len(q.workers)
@@ -225,7 +250,9 @@ func (q *queue) signal(ctx context.Context) error {
 				}
 				return false
 			}
-			if sendWork() {
+			sent := sendWork()
+			q.heartbeat()
+			if sent {
 				delete(q.workers, w)
 				break loop
 			}
@@ -236,6 +263,7 @@ func (q *queue) signal(ctx context.Context) error {
 
 func (q *queue) start() error {
 	for {
+		q.heartbeat()
 		select {
 		case <-q.ctx.Done():
 			return q.ctx.Err()
@@ -247,6 +275,11 @@ func (q *queue) start() error {
 	}
 }
 
+// heartbeat records that the scheduling loop made progress.
+func (q *queue) heartbeat() {
+	atomic.StoreInt64(&q.beat, time.Now().Unix())
+}
+
 type worker struct {
 	kind    string
 	typ     string
@@ -8,6 +8,7 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -47,6 +48,22 @@ func TestQueue(t *testing.T) {
 	}
 }
 
+func TestQueuePing(t *testing.T) {
+	ctx := context.Background()
+	q := &queue{
+		interval: time.Minute,
+		beat:     time.Now().Unix(),
+	}
+	if err := q.Ping(ctx); err != nil {
+		t.Errorf("Want healthy queue, got %s", err)
+	}
+
+	atomic.StoreInt64(&q.beat, time.Now().Add(-10*q.interval).Unix())
+	if err := q.Ping(ctx); err != errStalled {
+		t.Errorf("Want errStalled, got %v", err)
+	}
+}
+
 func TestQueueCancel(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -35,6 +35,10 @@ type Server struct {
 	Key     string
 	Host    string
 	Handler http.Handler
+
+	// Timeout is the duration the server waits for in-flight
+	// requests to complete before shutting down.
+	Timeout time.Duration
 }
 
 const timeoutGracefulShutdown = 5 * time.Second
@@ -62,7 +66,7 @@ func (s Server) listenAndServe(ctx context.Context) error {
 	g.Go(func() error {
 		<-ctx.Done()
 
-		ctxShutdown, cancelFunc := context.WithTimeout(context.Background(), timeoutGracefulShutdown)
+		ctxShutdown, cancelFunc := context.WithTimeout(context.Background(), s.shutdownTimeout())
 		defer cancelFunc()
 
 		return s1.Shutdown(ctxShutdown)
@@ -92,7 +96,7 @@ func (s Server) listenAndServeTLS(ctx context.Context) error {
 		<-ctx.Done()
 
 		var gShutdown errgroup.Group
-		ctxShutdown, cancelFunc := context.WithTimeout(context.Background(), timeoutGracefulShutdown)
+		ctxShutdown, cancelFunc := context.WithTimeout(context.Background(), s.shutdownTimeout())
 		defer cancelFunc()
 
 		gShutdown.Go(func() error {
@@ -138,7 +142,7 @@ func (s Server) listenAndServeAcme(ctx context.Context) error {
 		<-ctx.Done()
 
 		var gShutdown errgroup.Group
-		ctxShutdown, cancelFunc := context.WithTimeout(context.Background(), timeoutGracefulShutdown)
+		ctxShutdown, cancelFunc := context.WithTimeout(context.Background(), s.shutdownTimeout())
 		defer cancelFunc()
 
 		gShutdown.Go(func() error {
@@ -153,6 +157,15 @@ func (s Server) listenAndServeAcme(ctx context.Context) error {
 	return g.Wait()
 }
 
+// helper function returns the graceful shutdown timeout,
+// falling back to the default if not configured.
+func (s Server) shutdownTimeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return timeoutGracefulShutdown
+}
+
 func redirect(w http.ResponseWriter, req *http.Request) {
 	target := "https://" + req.Host + req.URL.Path
 	http.Redirect(w, req, target, http.StatusTemporaryRedirect)
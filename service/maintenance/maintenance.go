@@ -0,0 +1,97 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/drone/drone/core"
+)
+
+// DefaultMessage is displayed to users when maintenance mode
+// is enabled without a message.
+const DefaultMessage = "The system is undergoing maintenance. Please try again later."
+
+// ttl is the duration the maintenance mode is cached before it
+// is re-read from the datastore. Changes made by other server
+// instances are visible after at most this duration.
+const ttl = 5 * time.Second
+
+// New returns a new maintenance service. The maintenance mode is
+// persisted to the datastore, so it is shared by all server
+// instances and survives restarts.
+func New(store core.MaintenanceStore) core.MaintenanceService {
+	return &service{store: store}
+}
+
+type service struct {
+	sync.Mutex
+
+	store  core.MaintenanceStore
+	cached *core.Maintenance
+	expiry time.Time
+}
+
+func (s *service) Find(ctx context.Context) (*core.Maintenance, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.cached != nil && time.Now().Before(s.expiry) {
+		mode := *s.cached
+		return &mode, nil
+	}
+	mode, err := s.store.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cache(mode)
+	return mode, nil
+}
+
+func (s *service) Enable(ctx context.Context, message string) error {
+	if message == "" {
+		message = DefaultMessage
+	}
+	return s.update(ctx, &core.Maintenance{
+		Enabled: true,
+		Message: message,
+		Updated: time.Now().Unix(),
+	})
+}
+
+func (s *service) Disable(ctx context.Context) error {
+	return s.update(ctx, &core.Maintenance{
+		Updated: time.Now().Unix(),
+	})
+}
+
+func (s *service) update(ctx context.Context, mode *core.Maintenance) error {
+	s.Lock()
+	defer s.Unlock()
+	err := s.store.Update(ctx, mode)
+	if err != nil {
+		return err
+	}
+	s.cache(mode)
+	return nil
+}
+
+// helper function caches a copy of the maintenance mode.
+func (s *service) cache(mode *core.Maintenance) {
+	cached := *mode
+	s.cached = &cached
+	s.expiry = time.Now().Add(ttl)
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+var noContext = context.Background()
+
+func TestMaintenance(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mock.NewMockMaintenanceStore(controller)
+	store.EXPECT().Find(gomock.Any()).Return(&core.Maintenance{}, nil).Times(1)
+	store.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	service := New(store)
+
+	// the maintenance mode is read from the store once and
+	// then cached.
+	mode, _ := service.Find(noContext)
+	if mode.Enabled {
+		t.Errorf("Expect maintenance mode disabled by default")
+	}
+	service.Find(noContext)
+
+	service.Enable(noContext, "")
+	mode, _ = service.Find(noContext)
+	if !mode.Enabled {
+		t.Errorf("Expect maintenance mode enabled")
+	}
+	if got, want := mode.Message, DefaultMessage; got != want {
+		t.Errorf("Want message %q, got %q", want, got)
+	}
+
+	service.Enable(noContext, "database upgrade in progress")
+	mode, _ = service.Find(noContext)
+	if got, want := mode.Message, "database upgrade in progress"; got != want {
+		t.Errorf("Want message %q, got %q", want, got)
+	}
+
+	service.Disable(noContext)
+	mode, _ = service.Find(noContext)
+	if mode.Enabled {
+		t.Errorf("Expect maintenance mode disabled")
+	}
+}
+
+func TestMaintenance_Expired(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	// the maintenance mode is enabled by another server
+	// instance after the cache is populated.
+	store := mock.NewMockMaintenanceStore(controller)
+	store.EXPECT().Find(gomock.Any()).Return(&core.Maintenance{}, nil)
+	store.EXPECT().Find(gomock.Any()).Return(&core.Maintenance{Enabled: true}, nil)
+
+	service := New(store).(*service)
+	service.Find(noContext)
+	service.expiry = time.Now().Add(-time.Second)
+
+	mode, _ := service.Find(noContext)
+	if !mode.Enabled {
+		t.Errorf("Expect maintenance mode re-read from the store")
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banner
+
+import (
+	"context"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/shared/db"
+)
+
+// New returns a new Banner database store.
+func New(db *db.DB) core.BannerStore {
+	return &bannerStore{db}
+}
+
+type bannerStore struct {
+	db *db.DB
+}
+
+// List returns a list of all banners from the datastore.
+func (s *bannerStore) List(ctx context.Context) ([]*core.Banner, error) {
+	var out []*core.Banner
+	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+		params := map[string]interface{}{}
+		stmt, args, err := binder.BindNamed(queryAll, params)
+		if err != nil {
+			return err
+		}
+		rows, err := queryer.Query(stmt, args...)
+		if err != nil {
+			return err
+		}
+		out, err = scanRows(rows)
+		return err
+	})
+	return out, err
+}
+
+// ListActive returns a list of banners from the datastore that
+// are displayed at the given unix timestamp.
+func (s *bannerStore) ListActive(ctx context.Context, now int64) ([]*core.Banner, error) {
+	var out []*core.Banner
+	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+		params := map[string]interface{}{"now": now}
+		stmt, args, err := binder.BindNamed(queryActive, params)
+		if err != nil {
+			return err
+		}
+		rows, err := queryer.Query(stmt, args...)
+		if err != nil {
+			return err
+		}
+		out, err = scanRows(rows)
+		return err
+	})
+	return out, err
+}
+
+// Find returns a banner from the datastore.
+func (s *bannerStore) Find(ctx context.Context, id int64) (*core.Banner, error) {
+	out := &core.Banner{ID: id}
+	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+		params := toParams(out)
+		query, args, err := binder.BindNamed(queryKey, params)
+		if err != nil {
+			return err
+		}
+		row := queryer.QueryRow(query, args...)
+		return scanRow(row, out)
+	})
+	return out, err
+}
+
+// Create persists a new banner to the datastore.
+func (s *bannerStore) Create(ctx context.Context, banner *core.Banner) error {
+	if s.db.Driver() == db.Postgres {
+		return s.createPostgres(ctx, banner)
+	}
+	return s.create(ctx, banner)
+}
+
+func (s *bannerStore) create(ctx context.Context, banner *core.Banner) error {
+	return s.db.Lock(func(execer db.Execer, binder db.Binder) error {
+		params := toParams(banner)
+		stmt, args, err := binder.BindNamed(stmtInsert, params)
+		if err != nil {
+			return err
+		}
+		res, err := execer.Exec(stmt, args...)
+		if err != nil {
+			return err
+		}
+		banner.ID, err = res.LastInsertId()
+		return err
+	})
+}
+
+func (s *bannerStore) createPostgres(ctx context.Context, banner *core.Banner) error {
+	return s.db.Lock(func(execer db.Execer, binder db.Binder) error {
+		params := toParams(banner)
+		stmt, args, err := binder.BindNamed(stmtInsertPg, params)
+		if err != nil {
+			return err
+		}
+		return execer.QueryRow(stmt, args...).Scan(&banner.ID)
+	})
+}
+
+// Update persists an updated banner to the datastore.
+func (s *bannerStore) Update(ctx context.Context, banner *core.Banner) error {
+	return s.db.Lock(func(execer db.Execer, binder db.Binder) error {
+		params := toParams(banner)
+		stmt, args, err := binder.BindNamed(stmtUpdate, params)
+		if err != nil {
+			return err
+		}
+		_, err = execer.Exec(stmt, args...)
+		return err
+	})
+}
+
+// Delete deletes a banner from the datastore.
+func (s *bannerStore) Delete(ctx context.Context, banner *core.Banner) error {
+	return s.db.Lock(func(execer db.Execer, binder db.Binder) error {
+		params := toParams(banner)
+		stmt, args, err := binder.BindNamed(stmtDelete, params)
+		if err != nil {
+			return err
+		}
+		_, err = execer.Exec(stmt, args...)
+		return err
+	})
+}
+
+const queryBase = `
+SELECT
+ banner_id
+,banner_message
+,banner_level
+,banner_dismissible
+,banner_starts
+,banner_ends
+,banner_created
+,banner_updated
+`
+
+const queryKey = queryBase + `
+FROM banners
+WHERE banner_id = :banner_id
+LIMIT 1
+`
+
+const queryAll = queryBase + `
+FROM banners
+ORDER BY banner_id DESC
+`
+
+const queryActive = queryBase + `
+FROM banners
+WHERE (banner_starts = 0 OR banner_starts <= :now)
+  AND (banner_ends = 0 OR banner_ends > :now)
+ORDER BY banner_id DESC
+`
+
+const stmtInsert = `
+INSERT INTO banners (
+ banner_message
+,banner_level
+,banner_dismissible
+,banner_starts
+,banner_ends
+,banner_created
+,banner_updated
+) VALUES (
+ :banner_message
+,:banner_level
+,:banner_dismissible
+,:banner_starts
+,:banner_ends
+,:banner_created
+,:banner_updated
+)
+`
+
+const stmtInsertPg = stmtInsert + `
+RETURNING banner_id
+`
+
+const stmtUpdate = `
+UPDATE banners SET
+ banner_message = :banner_message
+,banner_level = :banner_level
+,banner_dismissible = :banner_dismissible
+,banner_starts = :banner_starts
+,banner_ends = :banner_ends
+,banner_updated = :banner_updated
+WHERE banner_id = :banner_id
+`
+
+const stmtDelete = `
+DELETE FROM banners
+WHERE banner_id = :banner_id
+`
@@ -0,0 +1,166 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package banner
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/shared/db/dbtest"
+)
+
+var noContext = context.TODO()
+
+func TestBanner(t *testing.T) {
+	conn, err := dbtest.Connect()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func() {
+		dbtest.Reset(conn)
+		dbtest.Disconnect(conn)
+	}()
+
+	store := New(conn).(*bannerStore)
+	t.Run("Create", testBannerCreate(store))
+}
+
+func testBannerCreate(store *bannerStore) func(t *testing.T) {
+	return func(t *testing.T) {
+		item := &core.Banner{
+			Message:     "scheduled maintenance on saturday",
+			Level:       core.BannerWarning,
+			Dismissible: true,
+			Starts:      100,
+			Ends:        200,
+			Created:     1,
+			Updated:     2,
+		}
+		err := store.Create(noContext, item)
+		if err != nil {
+			t.Error(err)
+		}
+		if item.ID == 0 {
+			t.Errorf("Want banner ID assigned, got %d", item.ID)
+		}
+
+		t.Run("Find", testBannerFind(store, item))
+		t.Run("List", testBannerList(store, item))
+		t.Run("ListActive", testBannerListActive(store, item))
+		t.Run("Update", testBannerUpdate(store, item))
+		t.Run("Delete", testBannerDelete(store, item))
+	}
+}
+
+func testBannerFind(store *bannerStore, banner *core.Banner) func(t *testing.T) {
+	return func(t *testing.T) {
+		item, err := store.Find(noContext, banner.ID)
+		if err != nil {
+			t.Error(err)
+		} else {
+			t.Run("Fields", testBanner(item))
+		}
+	}
+}
+
+func testBannerList(store *bannerStore, banner *core.Banner) func(t *testing.T) {
+	return func(t *testing.T) {
+		list, err := store.List(noContext)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := len(list), 1; got != want {
+			t.Errorf("Want count %d, got %d", want, got)
+		} else {
+			t.Run("Fields", testBanner(list[0]))
+		}
+	}
+}
+
+func testBannerListActive(store *bannerStore, banner *core.Banner) func(t *testing.T) {
+	return func(t *testing.T) {
+		tests := []struct {
+			now   int64
+			count int
+		}{
+			{now: 99, count: 0},
+			{now: 100, count: 1},
+			{now: 199, count: 1},
+			{now: 200, count: 0},
+		}
+		for _, test := range tests {
+			list, err := store.ListActive(noContext, test.now)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got, want := len(list), test.count; got != want {
+				t.Errorf("Want count %d at %d, got %d", want, test.now, got)
+			}
+		}
+	}
+}
+
+func testBannerUpdate(store *bannerStore, banner *core.Banner) func(t *testing.T) {
+	return func(t *testing.T) {
+		before, err := store.Find(noContext, banner.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		before.Level = core.BannerInfo
+		err = store.Update(noContext, before)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		after, err := store.Find(noContext, banner.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := after.Level, core.BannerInfo; got != want {
+			t.Errorf("Want updated level %q, got %q", want, got)
+		}
+	}
+}
+
+func testBannerDelete(store *bannerStore, banner *core.Banner) func(t *testing.T) {
+	return func(t *testing.T) {
+		err := store.Delete(noContext, banner)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = store.Find(noContext, banner.ID)
+		if got, want := sql.ErrNoRows, err; got != want {
+			t.Errorf("Want sql.ErrNoRows, got %v", got)
+		}
+	}
+}
+
+func testBanner(item *core.Banner) func(t *testing.T) {
+	return func(t *testing.T) {
+		if got, want := item.Message, "scheduled maintenance on saturday"; got != want {
+			t.Errorf("Want banner message %q, got %q", want, got)
+		}
+		if got, want := item.Level, core.BannerWarning; got != want {
+			t.Errorf("Want banner level %q, got %q", want, got)
+		}
+		if got, want := item.Dismissible, true; got != want {
+			t.Errorf("Want banner dismissible %v, got %v", want, got)
+		}
+		if got, want := item.Starts, int64(100); got != want {
+			t.Errorf("Want banner starts %d, got %d", want, got)
+		}
+		if got, want := item.Ends, int64(200); got != want {
+			t.Errorf("Want banner ends %d, got %d", want, got)
+		}
+	}
+}
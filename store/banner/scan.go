@@ -0,0 +1,69 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package banner
+
+import (
+	"database/sql"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/shared/db"
+)
+
+// helper function converts the Banner structure to a set
+// of named query parameters.
+func toParams(banner *core.Banner) map[string]interface{} {
+	return map[string]interface{}{
+		"banner_id":          banner.ID,
+		"banner_message":     banner.Message,
+		"banner_level":       banner.Level,
+		"banner_dismissible": banner.Dismissible,
+		"banner_starts":      banner.Starts,
+		"banner_ends":        banner.Ends,
+		"banner_created":     banner.Created,
+		"banner_updated":     banner.Updated,
+	}
+}
+
+// helper function scans the sql.Row and copies the column
+// values to the destination object.
+func scanRow(scanner db.Scanner, dst *core.Banner) error {
+	return scanner.Scan(
+		&dst.ID,
+		&dst.Message,
+		&dst.Level,
+		&dst.Dismissible,
+		&dst.Starts,
+		&dst.Ends,
+		&dst.Created,
+		&dst.Updated,
+	)
+}
+
+// helper function scans the sql.Row and copies the column
+// values to the destination object.
+func scanRows(rows *sql.Rows) ([]*core.Banner, error) {
+	defer rows.Close()
+
+	banners := []*core.Banner{}
+	for rows.Next() {
+		banner := new(core.Banner)
+		err := scanRow(rows, banner)
+		if err != nil {
+			return nil, err
+		}
+		banners = append(banners, banner)
+	}
+	return banners, nil
+}
@@ -82,10 +82,31 @@ func (s *buildStore) FindRef(ctx context.Context, repo int64, ref string) (*core
 	return out, err
 }
 
+// FindTag returns the latest successful tag build. Tags are
+// ordered by build, not by version, so a successful rebuild of
+// an older tag makes it the latest tag.
+func (s *buildStore) FindTag(ctx context.Context, repo int64, branch string) (*core.Build, error) {
+	out := &core.Build{RepoID: repo, Event: core.EventTag, Status: core.StatusPassing, Target: branch}
+	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+		params := toParams(out)
+		stmt := queryRowTag
+		if branch != "" {
+			stmt = queryRowTagTarget
+		}
+		query, args, err := binder.BindNamed(stmt, params)
+		if err != nil {
+			return err
+		}
+		row := queryer.QueryRow(query, args...)
+		return scanRow(row, out)
+	})
+	return out, err
+}
+
 // List returns a list of builds from the datastore by repository id.
 func (s *buildStore) List(ctx context.Context, repo int64, limit, offset int) ([]*core.Build, error) {
 	var out []*core.Build
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{
 			"build_repo_id": repo,
 			"limit":         limit,
@@ -108,7 +129,7 @@ func (s *buildStore) List(ctx context.Context, repo int64, limit, offset int) ([
 // ListRef returns a list of builds from the datastore by ref.
 func (s *buildStore) ListRef(ctx context.Context, repo int64, ref string, limit, offset int) ([]*core.Build, error) {
 	var out []*core.Build
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{
 			"build_repo_id": repo,
 			"build_ref":     ref,
@@ -129,6 +150,54 @@ func (s *buildStore) ListRef(ctx context.Context, repo int64, ref string, limit,
 	return out, err
 }
 
+// ListBefore returns a list of builds from the datastore with an
+// identifier less than the cursor.
+func (s *buildStore) ListBefore(ctx context.Context, repo, cursor int64, limit int) ([]*core.Build, error) {
+	var out []*core.Build
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
+		params := map[string]interface{}{
+			"build_repo_id": repo,
+			"build_id":      cursor,
+			"limit":         limit,
+		}
+		stmt, args, err := binder.BindNamed(queryBefore, params)
+		if err != nil {
+			return err
+		}
+		rows, err := queryer.Query(stmt, args...)
+		if err != nil {
+			return err
+		}
+		out, err = scanRows(rows)
+		return err
+	})
+	return out, err
+}
+
+// ListSender returns a list of builds from the datastore by sender
+// login, across all repositories.
+func (s *buildStore) ListSender(ctx context.Context, sender string, limit, offset int) ([]*core.Build, error) {
+	var out []*core.Build
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
+		params := map[string]interface{}{
+			"build_sender": sender,
+			"limit":        limit,
+			"offset":       offset,
+		}
+		stmt, args, err := binder.BindNamed(querySender, params)
+		if err != nil {
+			return err
+		}
+		rows, err := queryer.Query(stmt, args...)
+		if err != nil {
+			return err
+		}
+		out, err = scanRows(rows)
+		return err
+	})
+	return out, err
+}
+
 // LatestBranches returns a list of the latest build by branch.
 func (s *buildStore) LatestBranches(ctx context.Context, repo int64) ([]*core.Build, error) {
 	return s.latest(ctx, repo, "branch")
@@ -525,6 +594,25 @@ ORDER BY build_id DESC
 LIMIT 1
 `
 
+const queryRowTag = queryBase + `
+FROM builds
+WHERE build_repo_id = :build_repo_id
+  AND build_event = :build_event
+  AND build_status = :build_status
+ORDER BY build_id DESC
+LIMIT 1
+`
+
+const queryRowTagTarget = queryBase + `
+FROM builds
+WHERE build_repo_id = :build_repo_id
+  AND build_event = :build_event
+  AND build_status = :build_status
+  AND build_target = :build_target
+ORDER BY build_id DESC
+LIMIT 1
+`
+
 const queryRepo = queryBase + `
 FROM builds
 WHERE build_repo_id = :build_repo_id
@@ -532,6 +620,21 @@ ORDER BY build_id DESC
 LIMIT :limit OFFSET :offset
 `
 
+const queryBefore = queryBase + `
+FROM builds
+WHERE build_repo_id = :build_repo_id
+  AND build_id < :build_id
+ORDER BY build_id DESC
+LIMIT :limit
+`
+
+const querySender = queryBase + `
+FROM builds
+WHERE build_sender = :build_sender
+ORDER BY build_id DESC
+LIMIT :limit OFFSET :offset
+`
+
 const queryPending = queryBase + `
 FROM builds
 WHERE EXISTS (
@@ -36,6 +36,7 @@ func TestBuild(t *testing.T) {
 	t.Run("Pending", testBuildPending(store))
 	t.Run("Running", testBuildRunning(store))
 	t.Run("Latest", testBuildLatest(store))
+	t.Run("FindTag", testBuildFindTag(store))
 }
 
 func testBuildCreate(store *buildStore) func(t *testing.T) {
@@ -46,6 +47,7 @@ func testBuildCreate(store *buildStore) func(t *testing.T) {
 			Event:  core.EventPush,
 			Ref:    "refs/heads/master",
 			Target: "master",
+			Sender: "octocat",
 		}
 		stage := &core.Stage{
 			RepoID: 42,
@@ -66,6 +68,8 @@ func testBuildCreate(store *buildStore) func(t *testing.T) {
 		t.Run("FindRef", testBuildFindRef(store, build))
 		t.Run("List", testBuildList(store, build))
 		t.Run("ListRef", testBuildListRef(store, build))
+		t.Run("ListSender", testBuildListSender(store, build))
+		t.Run("ListBefore", testBuildListBefore(store, build))
 		t.Run("Update", testBuildUpdate(store, build))
 		t.Run("Locking", testBuildLocking(store, build))
 		t.Run("Delete", testBuildDelete(store, build))
@@ -135,6 +139,45 @@ func testBuildListRef(store *buildStore, build *core.Build) func(t *testing.T) {
 	}
 }
 
+func testBuildListSender(store *buildStore, build *core.Build) func(t *testing.T) {
+	return func(t *testing.T) {
+		list, err := store.ListSender(noContext, build.Sender, 10, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := len(list), 1; got != want {
+			t.Errorf("Want list count %d, got %d", want, got)
+		} else {
+			t.Run("Fields", testBuild(list[0]))
+		}
+	}
+}
+
+func testBuildListBefore(store *buildStore, build *core.Build) func(t *testing.T) {
+	return func(t *testing.T) {
+		list, err := store.ListBefore(noContext, build.RepoID, build.ID+1, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := len(list), 1; got != want {
+			t.Errorf("Want list count %d, got %d", want, got)
+		} else {
+			t.Run("Fields", testBuild(list[0]))
+		}
+
+		list, err = store.ListBefore(noContext, build.RepoID, build.ID, 10)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := len(list), 0; got != want {
+			t.Errorf("Want list count %d, got %d", want, got)
+		}
+	}
+}
+
 func testBuildUpdate(store *buildStore, build *core.Build) func(t *testing.T) {
 	return func(t *testing.T) {
 		before := &core.Build{
@@ -480,3 +523,57 @@ func testBuild(item *core.Build) func(t *testing.T) {
 		}
 	}
 }
+
+func testBuildFindTag(store *buildStore) func(t *testing.T) {
+	return func(t *testing.T) {
+		_ = store.db.Update(func(execer db.Execer, binder db.Binder) error {
+			_, _ = execer.Exec("DELETE FROM stages")
+			_, _ = execer.Exec("DELETE FROM latest")
+			_, _ = execer.Exec("DELETE FROM builds")
+			return nil
+		})
+
+		// the v1.0.0 tag is built from master and the v2.0.0 tag
+		// from develop. The build of the v3.0.0 tag fails, and a
+		// rebuild of the v2.0.0 tag is killed.
+		builds := []*core.Build{
+			{RepoID: 1, Number: 1, Event: core.EventTag, Status: core.StatusPassing, Ref: "refs/tags/v1.0.0", Target: "master"},
+			{RepoID: 1, Number: 2, Event: core.EventTag, Status: core.StatusPassing, Ref: "refs/tags/v2.0.0", Target: "develop"},
+			{RepoID: 1, Number: 3, Event: core.EventTag, Status: core.StatusFailing, Ref: "refs/tags/v3.0.0", Target: "master"},
+			{RepoID: 1, Number: 4, Event: core.EventTag, Status: core.StatusKilled, Ref: "refs/tags/v2.0.0", Target: "develop"},
+			{RepoID: 1, Number: 5, Event: core.EventPush, Status: core.StatusPassing, Ref: "refs/heads/master", Target: "master"},
+		}
+		for _, build := range builds {
+			if err := store.Create(noContext, build, nil); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		build, err := store.FindTag(noContext, 1, "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := build.Ref, "refs/tags/v2.0.0"; got != want {
+			t.Errorf("Want tag %s, got %s", want, got)
+		}
+		if got, want := build.Number, int64(2); got != want {
+			t.Errorf("Want successful build %d, got %d", want, got)
+		}
+
+		build, err = store.FindTag(noContext, 1, "master")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := build.Ref, "refs/tags/v1.0.0"; got != want {
+			t.Errorf("Want tag %s, got %s", want, got)
+		}
+
+		_, err = store.FindTag(noContext, 1, "feature")
+		if err != sql.ErrNoRows {
+			t.Errorf("Want sql.ErrNoRows, got %v", err)
+		}
+	}
+}
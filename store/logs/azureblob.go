@@ -74,6 +74,17 @@ func (az *azureBlobStore) Delete(ctx context.Context, step int64) error {
 	return err
 }
 
+// Ping verifies the container exists and is reachable with
+// the configured credentials.
+func (az *azureBlobStore) Ping(ctx context.Context) error {
+	err := az.getContainerURL()
+	if err != nil {
+		return err
+	}
+	_, err = az.containerURL.GetProperties(ctx, azblob.LeaseAccessConditions{})
+	return err
+}
+
 func (az *azureBlobStore) getContainerURL() error {
 	if az.containerURL != nil {
 		return nil
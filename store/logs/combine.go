@@ -59,3 +59,14 @@ func (s *combined) Delete(ctx context.Context, step int64) error {
 	}
 	return err
 }
+
+// Ping verifies the primary log store is reachable, if the
+// primary log store supports it.
+func (s *combined) Ping(ctx context.Context) error {
+	if p, ok := s.primary.(interface {
+		Ping(context.Context) error
+	}); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
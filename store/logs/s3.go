@@ -94,6 +94,16 @@ func (s *s3store) Delete(ctx context.Context, step int64) error {
 	return err
 }
 
+// Ping verifies the bucket exists and is reachable with the
+// configured credentials.
+func (s *s3store) Ping(ctx context.Context) error {
+	svc := s3.New(s.session)
+	_, err := svc.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	return err
+}
+
 func (s *s3store) key(step int64) string {
 	return path.Join("/", s.prefix, fmt.Sprint(step))
 }
@@ -0,0 +1,113 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/shared/db"
+)
+
+// New returns a new maintenance mode database store. The
+// maintenance mode is stored as a single row, so it is shared
+// by all server instances and survives restarts.
+func New(db *db.DB) core.MaintenanceStore {
+	return &maintenanceStore{db}
+}
+
+type maintenanceStore struct {
+	db *db.DB
+}
+
+// Find returns the maintenance mode from the datastore. If
+// the maintenance mode was never set, it is disabled.
+func (s *maintenanceStore) Find(ctx context.Context) (*core.Maintenance, error) {
+	out := new(core.Maintenance)
+	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+		params := map[string]interface{}{"maintenance_id": rowID}
+		query, args, err := binder.BindNamed(queryKey, params)
+		if err != nil {
+			return err
+		}
+		row := queryer.QueryRow(query, args...)
+		return row.Scan(
+			&out.Enabled,
+			&out.Message,
+			&out.Updated,
+		)
+	})
+	if err == sql.ErrNoRows {
+		return new(core.Maintenance), nil
+	}
+	return out, err
+}
+
+// Update persists the maintenance mode to the datastore.
+func (s *maintenanceStore) Update(ctx context.Context, maintenance *core.Maintenance) error {
+	return s.db.Update(func(execer db.Execer, binder db.Binder) error {
+		params := map[string]interface{}{
+			"maintenance_id":      rowID,
+			"maintenance_enabled": maintenance.Enabled,
+			"maintenance_message": maintenance.Message,
+			"maintenance_updated": maintenance.Updated,
+		}
+		stmt, args, err := binder.BindNamed(stmtDelete, params)
+		if err != nil {
+			return err
+		}
+		if _, err := execer.Exec(stmt, args...); err != nil {
+			return err
+		}
+		stmt, args, err = binder.BindNamed(stmtInsert, params)
+		if err != nil {
+			return err
+		}
+		_, err = execer.Exec(stmt, args...)
+		return err
+	})
+}
+
+// the maintenance mode is stored in a single row.
+const rowID = 1
+
+const queryKey = `
+SELECT
+ maintenance_enabled
+,maintenance_message
+,maintenance_updated
+FROM maintenance
+WHERE maintenance_id = :maintenance_id
+`
+
+const stmtDelete = `
+DELETE FROM maintenance
+WHERE maintenance_id = :maintenance_id
+`
+
+const stmtInsert = `
+INSERT INTO maintenance (
+ maintenance_id
+,maintenance_enabled
+,maintenance_message
+,maintenance_updated
+) VALUES (
+ :maintenance_id
+,:maintenance_enabled
+,:maintenance_message
+,:maintenance_updated
+)
+`
@@ -0,0 +1,76 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/shared/db/dbtest"
+)
+
+var noContext = context.TODO()
+
+func TestMaintenance(t *testing.T) {
+	conn, err := dbtest.Connect()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func() {
+		dbtest.Reset(conn)
+		dbtest.Disconnect(conn)
+	}()
+
+	store := New(conn).(*maintenanceStore)
+
+	mode, err := store.Find(noContext)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if mode.Enabled {
+		t.Errorf("Want maintenance mode disabled by default")
+	}
+
+	err = store.Update(noContext, &core.Maintenance{
+		Enabled: true,
+		Message: "database upgrade in progress",
+		Updated: 1,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mode, err = store.Find(noContext)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !mode.Enabled {
+		t.Errorf("Want maintenance mode enabled")
+	}
+	if got, want := mode.Message, "database upgrade in progress"; got != want {
+		t.Errorf("Want message %q, got %q", want, got)
+	}
+
+	err = store.Update(noContext, &core.Maintenance{Updated: 2})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mode, err = store.Find(noContext)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if mode.Enabled {
+		t.Errorf("Want maintenance mode disabled")
+	}
+	if got, want := mode.Updated, int64(2); got != want {
+		t.Errorf("Want updated %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orphan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/shared/db"
+)
+
+// New returns a new orphan database store.
+func New(db *db.DB) core.OrphanStore {
+	return &orphanStore{db}
+}
+
+type orphanStore struct {
+	db *db.DB
+}
+
+// Count returns a count of orphaned records.
+func (s *orphanStore) Count(ctx context.Context) (*core.Orphans, error) {
+	out := new(core.Orphans)
+	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+		if err := queryer.QueryRow(queryBuilds).Scan(&out.Builds); err != nil {
+			return err
+		}
+		if err := queryer.QueryRow(queryStages).Scan(&out.Stages); err != nil {
+			return err
+		}
+		if err := queryer.QueryRow(querySteps).Scan(&out.Steps); err != nil {
+			return err
+		}
+		if err := queryer.QueryRow(queryCards).Scan(&out.Cards); err != nil {
+			return err
+		}
+		return queryer.QueryRow(queryLogs).Scan(&out.Logs)
+	})
+	return out, err
+}
+
+// Purge deletes orphaned records and returns a count of the
+// records deleted. Records are deleted from the bottom of the
+// hierarchy up, so that each statement only references parent
+// tables that have not yet been modified.
+//
+// Records are deleted in batches, each in its own transaction,
+// so that a large purge does not hold a long-running transaction
+// or lock. If the purge is interrupted, the remaining records
+// are still orphaned and are deleted when the purge is repeated.
+func (s *orphanStore) Purge(ctx context.Context) (*core.Orphans, error) {
+	out := new(core.Orphans)
+	var err error
+	if out.Logs, err = s.purge(ctx, "logs", "log_id", whereLogs); err != nil {
+		return out, err
+	}
+	if out.Cards, err = s.purge(ctx, "cards", "card_id", whereCards); err != nil {
+		return out, err
+	}
+	if out.Steps, err = s.purge(ctx, "steps", "step_id", whereSteps); err != nil {
+		return out, err
+	}
+	if out.Stages, err = s.purge(ctx, "stages", "stage_id", whereStages); err != nil {
+		return out, err
+	}
+	out.Builds, err = s.purge(ctx, "builds", "build_id", whereBuilds)
+	return out, err
+}
+
+// helper function deletes the orphaned records from the table in
+// batches, and returns the number of records deleted. Each batch
+// is selected by primary key, starting after the previous batch.
+func (s *orphanStore) purge(ctx context.Context, table, key, where string) (int64, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s %s AND %s > :cursor ORDER BY %s LIMIT %d",
+		key, table, where, key, key, batchSize)
+
+	var count, cursor int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		var ids []string
+		err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+			stmt, args, err := binder.BindNamed(query, map[string]interface{}{"cursor": cursor})
+			if err != nil {
+				return err
+			}
+			rows, err := queryer.Query(stmt, args...)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				if err := rows.Scan(&cursor); err != nil {
+					return err
+				}
+				ids = append(ids, strconv.FormatInt(cursor, 10))
+			}
+			return rows.Err()
+		})
+		if err != nil || len(ids) == 0 {
+			return count, err
+		}
+
+		stmt := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)", table, key, strings.Join(ids, ","))
+		err = s.db.Lock(func(execer db.Execer, binder db.Binder) error {
+			res, err := execer.Exec(stmt)
+			if err != nil {
+				return err
+			}
+			n, err := res.RowsAffected()
+			count += n
+			return err
+		})
+		if err != nil || len(ids) < batchSize {
+			return count, err
+		}
+	}
+}
+
+// batchSize is the maximum number of records deleted in
+// a single statement.
+var batchSize = 1000
+
+// a record is orphaned if its parent record, or any record
+// further up the repository, build, stage, step hierarchy,
+// no longer exists.
+
+const subqueryBuilds = `
+SELECT build_id FROM builds
+WHERE build_repo_id IN (SELECT repo_id FROM repos)
+`
+
+const subqueryStages = `
+SELECT stage_id FROM stages
+WHERE stage_build_id IN (` + subqueryBuilds + `)
+`
+
+const subquerySteps = `
+SELECT step_id FROM steps
+WHERE step_stage_id IN (` + subqueryStages + `)
+`
+
+const whereBuilds = `
+WHERE build_repo_id NOT IN (SELECT repo_id FROM repos)
+`
+
+const whereStages = `
+WHERE stage_build_id NOT IN (` + subqueryBuilds + `)
+`
+
+const whereSteps = `
+WHERE step_stage_id NOT IN (` + subqueryStages + `)
+`
+
+const whereLogs = `
+WHERE log_id NOT IN (` + subquerySteps + `)
+`
+
+const whereCards = `
+WHERE card_id NOT IN (` + subquerySteps + `)
+`
+
+const queryBuilds = `
+SELECT COUNT(*) FROM builds
+` + whereBuilds
+
+const queryStages = `
+SELECT COUNT(*) FROM stages
+` + whereStages
+
+const querySteps = `
+SELECT COUNT(*) FROM steps
+` + whereSteps
+
+const queryLogs = `
+SELECT COUNT(*) FROM logs
+` + whereLogs
+
+const queryCards = `
+SELECT COUNT(*) FROM cards
+` + whereCards
@@ -0,0 +1,121 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package orphan
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/build"
+	"github.com/drone/drone/store/card"
+	"github.com/drone/drone/store/logs"
+	"github.com/drone/drone/store/repos"
+	"github.com/drone/drone/store/shared/db/dbtest"
+	"github.com/drone/drone/store/step"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var noContext = context.TODO()
+
+func TestOrphans(t *testing.T) {
+	conn, err := dbtest.Connect()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func() {
+		dbtest.Reset(conn)
+		dbtest.Disconnect(conn)
+	}()
+
+	// seed with three repositories, each with a build, stage,
+	// step, log and card. The first two repositories are then
+	// deleted, which orphans their builds and all descendants.
+	repoz := repos.New(conn)
+	builds := build.New(conn)
+	steps := step.New(conn)
+	logz := logs.New(conn)
+	cards := card.New(conn)
+	var deleted []*core.Repository
+	for _, slug := range []string{"octocat/hello-world", "octocat/hello-mars", "octocat/spoon-knife"} {
+		repo := &core.Repository{UID: slug, Slug: slug}
+		if err := repoz.Create(noContext, repo); err != nil {
+			t.Error(err)
+			return
+		}
+		stage := &core.Stage{Number: 1}
+		if err := builds.Create(noContext, &core.Build{Number: 1, RepoID: repo.ID}, []*core.Stage{stage}); err != nil {
+			t.Error(err)
+			return
+		}
+		item := &core.Step{StageID: stage.ID, Number: 1}
+		if err := steps.Create(noContext, item); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := logz.Create(noContext, item.ID, bytes.NewBufferString("hello world")); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := cards.Create(noContext, item.ID, bytes.NewBufferString("{}")); err != nil {
+			t.Error(err)
+			return
+		}
+		if len(deleted) < 2 {
+			deleted = append(deleted, repo)
+		}
+	}
+	for _, repo := range deleted {
+		if err := repoz.Delete(noContext, repo); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	store := New(conn).(*orphanStore)
+	want := &core.Orphans{Builds: 2, Stages: 2, Steps: 2, Logs: 2, Cards: 2}
+
+	got, err := store.Count(noContext)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+
+	// records are purged one at a time, which verifies
+	// the purge continues after the first batch.
+	batchSize = 1
+	defer func() { batchSize = 1000 }()
+
+	got, err = store.Purge(noContext)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+
+	got, err = store.Count(noContext)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(got, &core.Orphans{}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	// the records of the remaining repository are untouched.
+	if n, _ := builds.Count(noContext); n != 1 {
+		t.Errorf("Want 1 remaining build, got %d", n)
+	}
+}
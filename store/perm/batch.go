@@ -0,0 +1,45 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perm
+
+import (
+	"context"
+
+	"github.com/drone/drone/core"
+)
+
+// NewBatcher wraps the batcher so that the cached permissions of
+// the user are evicted after a batch update is written. The batcher
+// writes to the permissions table directly, bypassing the store.
+// The permissions are evicted even if the batch fails, since the
+// batch may have been partially written.
+func NewBatcher(base core.Batcher, perms core.PermStore) core.Batcher {
+	cache, ok := perms.(*cacher)
+	if !ok {
+		return base
+	}
+	return &batcher{base: base, cache: cache}
+}
+
+type batcher struct {
+	base  core.Batcher
+	cache *cacher
+}
+
+func (b *batcher) Batch(ctx context.Context, user *core.User, batch *core.Batch) error {
+	err := b.base.Batch(ctx, user, batch)
+	b.cache.evictUser(user.ID)
+	return err
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drone/drone/core"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// cache key pattern used in the cache, comprised of the
+// repository unique identifier and user id.
+const cacheKey = "%s/%d"
+
+// NewCache wraps the store with a simple cache to store
+// repository permissions. The permissions are checked on
+// every repository api request, and are otherwise fetched
+// from the database for each request.
+func NewCache(base core.PermStore, size int, ttl time.Duration) core.PermStore {
+	cache, _ := lru.New(size)
+	return &cacher{
+		base:  base,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+type cacher struct {
+	base    core.PermStore
+	cache   *lru.Cache
+	ttl     time.Duration
+	publish func(key string)
+}
+
+type item struct {
+	expiry time.Time
+	perm   core.Perm
+}
+
+func (c *cacher) Find(ctx context.Context, repoUID string, userID int64) (*core.Perm, error) {
+	key := fmt.Sprintf(cacheKey, repoUID, userID)
+	now := time.Now()
+
+	// get the permissions from the cache. A copy is returned
+	// because callers may modify the permissions.
+	cached, ok := c.cache.Get(key)
+	if ok {
+		item := cached.(*item)
+		// if the item is expired it can be ejected
+		// from the cache, else if not expired we return
+		// the cached results.
+		if now.After(item.expiry) {
+			c.cache.Remove(key)
+		} else {
+			perm := item.perm
+			return &perm, nil
+		}
+	}
+
+	// get up-to-date permissions due to a cache miss
+	// or expired cache item.
+	perm, err := c.base.Find(ctx, repoUID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.Add(key, &item{
+		expiry: now.Add(c.ttl),
+		perm:   *perm,
+	})
+	return perm, nil
+}
+
+func (c *cacher) List(ctx context.Context, repoUID string) ([]*core.Collaborator, error) {
+	return c.base.List(ctx, repoUID)
+}
+
+// Update persists the permissions and then evicts them from
+// the cache. Evicting after the write prevents a concurrent Find
+// from caching the previous permissions.
+func (c *cacher) Update(ctx context.Context, perm *core.Perm) error {
+	err := c.base.Update(ctx, perm)
+	if err == nil {
+		c.evict(perm.RepoUID, perm.UserID)
+	}
+	return err
+}
+
+// Delete deletes the permissions and then evicts them from
+// the cache.
+func (c *cacher) Delete(ctx context.Context, perm *core.Perm) error {
+	err := c.base.Delete(ctx, perm)
+	if err == nil {
+		c.evict(perm.RepoUID, perm.UserID)
+	}
+	return err
+}
+
+// evict removes the permissions from the cache and notifies
+// the other server instances.
+func (c *cacher) evict(repoUID string, userID int64) {
+	c.remove(repoUID, userID)
+	c.notify(fmt.Sprintf(cacheKey, repoUID, userID))
+}
+
+// evictUser removes all permissions of the user from the cache
+// and notifies the other server instances.
+func (c *cacher) evictUser(userID int64) {
+	c.removeUser(userID)
+	c.notify(fmt.Sprintf(cacheKey, "*", userID))
+}
+
+// helper function removes the permissions from the local cache.
+func (c *cacher) remove(repoUID string, userID int64) {
+	c.cache.Remove(fmt.Sprintf(cacheKey, repoUID, userID))
+}
+
+// helper function removes all permissions of the user from the
+// local cache.
+func (c *cacher) removeUser(userID int64) {
+	suffix := fmt.Sprintf("/%d", userID)
+	for _, key := range c.cache.Keys() {
+		if strings.HasSuffix(key.(string), suffix) {
+			c.cache.Remove(key)
+		}
+	}
+}
+
+// helper function publishes the evicted cache key, if the
+// cache is shared with other server instances.
+func (c *cacher) notify(key string) {
+	if c.publish != nil {
+		c.publish(key)
+	}
+}
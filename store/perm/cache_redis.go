@@ -0,0 +1,78 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perm
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/service/redisdb"
+
+	"github.com/sirupsen/logrus"
+)
+
+// redis pub-sub channel used to broadcast evicted cache keys.
+const redisPubSubPerm = "drone-perm-evict"
+
+// NewCacheRedis wraps the store with a cache to store repository
+// permissions, where evictions are broadcast to all server
+// instances using redis pub-sub. Each server instance keeps
+// its own in-memory cache.
+func NewCacheRedis(base core.PermStore, size int, ttl time.Duration, r redisdb.RedisDB) core.PermStore {
+	c := NewCache(base, size, ttl).(*cacher)
+	c.publish = func(key string) {
+		err := r.Client().Publish(context.Background(), redisPubSubPerm, key).Err()
+		if err != nil {
+			logrus.WithError(err).
+				Warnln("perm: cannot publish cache eviction")
+		}
+	}
+	go r.Subscribe(context.Background(), redisPubSubPerm, 100, &evictor{c})
+	return c
+}
+
+// evictor removes the cache keys received from other
+// server instances from the local cache.
+type evictor struct {
+	cache *cacher
+}
+
+// ProcessMessage removes the cache key from the local cache.
+// It is a part of redisdb.PubSubProcessor implementation.
+func (e *evictor) ProcessMessage(key string) {
+	i := strings.LastIndex(key, "/")
+	if i == -1 {
+		return
+	}
+	userID, err := strconv.ParseInt(key[i+1:], 10, 64)
+	if err != nil {
+		return
+	}
+	if repoUID := key[:i]; repoUID == "*" {
+		e.cache.removeUser(userID)
+	} else {
+		e.cache.remove(repoUID, userID)
+	}
+}
+
+// ProcessError purges the local cache, because evictions may
+// have been missed while the subscription was interrupted.
+// It is a part of redisdb.PubSubProcessor implementation.
+func (e *evictor) ProcessError(err error) {
+	e.cache.cache.Purge()
+}
@@ -0,0 +1,151 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package perm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestCache(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPerm := &core.Perm{
+		UserID:  1,
+		RepoUID: "42",
+		Read:    true,
+		Write:   true,
+	}
+
+	base := mock.NewMockPermStore(controller)
+	base.EXPECT().Find(gomock.Any(), "42", int64(1)).Return(mockPerm, nil).Times(1)
+
+	store := NewCache(base, 10, time.Minute).(*cacher)
+	perm, err := store.Find(noContext, "42", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := store.cache.Len(), 1; got != want {
+		t.Errorf("Expect cache size %d, got %d", want, got)
+	}
+
+	// modifying the returned permissions must not modify
+	// the cached permissions.
+	perm.Write = false
+
+	perm, err = store.Find(noContext, "42", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if perm.Write == false {
+		t.Errorf("Expect cached write true, got false")
+	}
+}
+
+func TestCache_Expired(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPerm := &core.Perm{
+		UserID:  1,
+		RepoUID: "42",
+	}
+
+	base := mock.NewMockPermStore(controller)
+	base.EXPECT().Find(gomock.Any(), "42", int64(1)).Return(mockPerm, nil).Times(2)
+
+	store := NewCache(base, 10, time.Minute).(*cacher)
+	store.cache.Add("42/1", &item{
+		expiry: time.Now().Add(time.Hour * -1),
+		perm:   *mockPerm,
+	})
+	if _, err := store.Find(noContext, "42", 1); err != nil {
+		t.Error(err)
+	}
+	if _, err := store.Find(noContext, "42", 1); err != nil {
+		t.Error(err)
+	}
+	store.cache.Remove("42/1")
+	if _, err := store.Find(noContext, "42", 1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCache_Evict(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPerm := &core.Perm{
+		UserID:  1,
+		RepoUID: "42",
+	}
+
+	base := mock.NewMockPermStore(controller)
+	base.EXPECT().Find(gomock.Any(), "42", int64(1)).Return(mockPerm, nil)
+	base.EXPECT().Update(gomock.Any(), mockPerm).Return(nil)
+	base.EXPECT().Delete(gomock.Any(), mockPerm).Return(nil)
+
+	store := NewCache(base, 10, time.Minute).(*cacher)
+	if _, err := store.Find(noContext, "42", 1); err != nil {
+		t.Error(err)
+	}
+	if err := store.Update(noContext, mockPerm); err != nil {
+		t.Error(err)
+	}
+	if got, want := store.cache.Len(), 0; got != want {
+		t.Errorf("Expect cache evicted on update, got size %d", got)
+	}
+	if err := store.Delete(noContext, mockPerm); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCache_EvictBatch(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUser := &core.User{ID: 1}
+	mockBatch := &core.Batch{}
+
+	base := mock.NewMockPermStore(controller)
+	batcher := mock.NewMockBatcher(controller)
+	batcher.EXPECT().Batch(gomock.Any(), mockUser, mockBatch).Return(nil)
+
+	store := NewCache(base, 10, time.Minute).(*cacher)
+	store.cache.Add("42/1", &item{expiry: time.Now().Add(time.Hour)})
+	store.cache.Add("43/1", &item{expiry: time.Now().Add(time.Hour)})
+	store.cache.Add("42/2", &item{expiry: time.Now().Add(time.Hour)})
+
+	err := NewBatcher(batcher, store).Batch(noContext, mockUser, mockBatch)
+	if err != nil {
+		t.Error(err)
+	}
+	if got, want := store.cache.Keys(), []interface{}{"42/2"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Expect user permissions evicted, got keys %v", got)
+	}
+}
+
+func TestCache_EvictMessage(t *testing.T) {
+	store := NewCache(nil, 10, time.Minute).(*cacher)
+	store.cache.Add("42/1", &item{expiry: time.Now().Add(time.Hour)})
+	store.cache.Add("43/1", &item{expiry: time.Now().Add(time.Hour)})
+	store.cache.Add("42/2", &item{expiry: time.Now().Add(time.Hour)})
+
+	e := &evictor{store}
+	e.ProcessMessage("42/2")
+	if got, want := store.cache.Len(), 2; got != want {
+		t.Errorf("Expect cache size %d, got %d", want, got)
+	}
+	e.ProcessMessage("*/1")
+	if got, want := store.cache.Len(), 0; got != want {
+		t.Errorf("Expect cache size %d, got %d", want, got)
+	}
+}
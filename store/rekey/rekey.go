@@ -0,0 +1,148 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rekey re-encrypts encrypted database columns with
+// the primary database encryption key.
+package rekey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/drone/drone/store/shared/db"
+	"github.com/drone/drone/store/shared/encrypt"
+)
+
+// Column identifies an encrypted database column.
+type Column struct {
+	Table string
+	Key   string
+	Name  string
+}
+
+var (
+	// Secrets identifies the encrypted repository and
+	// organization secret columns.
+	Secrets = []Column{
+		{Table: "secrets", Key: "secret_id", Name: "secret_data"},
+		{Table: "orgsecrets", Key: "secret_id", Name: "secret_data"},
+	}
+
+	// Users identifies the encrypted user columns. These
+	// columns are only encrypted when the user table
+	// encryption feature flag is enabled.
+	Users = []Column{
+		{Table: "users", Key: "user_id", Name: "user_oauth_token"},
+		{Table: "users", Key: "user_id", Name: "user_oauth_refresh"},
+	}
+)
+
+// ErrCompat is returned when the encrypter runs in mixed
+// content mode. In this mode values that cannot be decrypted
+// are returned unchanged, and would be re-encrypted as if they
+// were plaintext.
+var ErrCompat = errors.New("rekey: cannot re-encrypt in mixed content mode")
+
+type row struct {
+	id   int64
+	data []byte
+}
+
+// Rekey decrypts every value in the columns and encrypts it
+// again with the primary key of the encrypter. It returns the
+// number of values that were re-encrypted. The values are
+// re-encrypted in a single transaction, so no values are
+// changed if any value cannot be decrypted.
+func Rekey(ctx context.Context, conn *db.DB, enc encrypt.Encrypter, columns ...Column) (int, error) {
+	if aesgcm, ok := enc.(*encrypt.Aesgcm); ok && aesgcm.Compat {
+		return 0, ErrCompat
+	}
+	var count int
+	err := conn.Update(func(execer db.Execer, binder db.Binder) error {
+		count = 0
+		for _, column := range columns {
+			n, err := rekey(execer, binder, enc, column)
+			if err != nil {
+				return err
+			}
+			count += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func rekey(execer db.Execer, binder db.Binder, enc encrypt.Encrypter, column Column) (int, error) {
+	rows, err := selectRows(execer, column)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	stmt := fmt.Sprintf("UPDATE %s SET %s = :data WHERE %s = :id", column.Table, column.Name, column.Key)
+	for _, row := range rows {
+		if len(row.data) == 0 {
+			continue
+		}
+		plaintext, err := enc.Decrypt(row.data)
+		if err != nil {
+			return count, fmt.Errorf("rekey: cannot decrypt %s.%s with id %d: %w",
+				column.Table, column.Name, row.id, err)
+		}
+		ciphertext, err := enc.Encrypt(plaintext)
+		if err != nil {
+			return count, err
+		}
+		params := map[string]interface{}{
+			"id":   row.id,
+			"data": ciphertext,
+		}
+		query, args, err := binder.BindNamed(stmt, params)
+		if err != nil {
+			return count, err
+		}
+		if _, err := execer.Exec(query, args...); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// helper function reads all values in the column. The rows
+// are read before any value is updated, since some drivers
+// do not allow statements to be executed in a transaction
+// while a result set is open.
+func selectRows(queryer db.Queryer, column Column) ([]*row, error) {
+	query := fmt.Sprintf("SELECT %s, %s FROM %s", column.Key, column.Name, column.Table)
+	res, err := queryer.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var rows []*row
+	for res.Next() {
+		dst := new(row)
+		if err := res.Scan(&dst.id, &dst.data); err != nil {
+			return nil, err
+		}
+		rows = append(rows, dst)
+	}
+	return rows, res.Err()
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+// +build !oss
+
+package rekey
+
+import (
+	"context"
+	"testing"
+
+	"github.com/drone/drone/core"
+	"github.com/drone/drone/store/repos"
+	"github.com/drone/drone/store/secret"
+	"github.com/drone/drone/store/shared/db/dbtest"
+	"github.com/drone/drone/store/shared/encrypt"
+)
+
+var noContext = context.TODO()
+
+func TestRekey(t *testing.T) {
+	conn, err := dbtest.Connect()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func() {
+		dbtest.Reset(conn)
+		dbtest.Disconnect(conn)
+	}()
+
+	// seeds the database with a dummy repository.
+	repo := &core.Repository{UID: "1", Slug: "octocat/hello-world"}
+	if err := repos.New(conn).Create(noContext, repo); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// seeds the database with a secret encrypted using the
+	// previous key.
+	prev, _ := encrypt.New("ea1c5a9145c8a5ce8231f8b186dbcabc")
+	item := &core.Secret{
+		RepoID: repo.ID,
+		Name:   "password",
+		Data:   "correct-horse-battery-staple",
+	}
+	if err := secret.New(conn, prev).Create(noContext, item); err != nil {
+		t.Error(err)
+		return
+	}
+
+	enc, _ := encrypt.New(
+		"fb4b4d6267c8a5ce8231f8b186dbca92",
+		"ea1c5a9145c8a5ce8231f8b186dbcabc",
+	)
+	n, err := Rekey(noContext, conn, enc, Secrets...)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := n, 1; got != want {
+		t.Errorf("Want %d values re-encrypted, got %d", want, got)
+	}
+
+	// the secret must be readable once the previous key
+	// is removed from the configuration.
+	next, _ := encrypt.New("fb4b4d6267c8a5ce8231f8b186dbca92")
+	found, err := secret.New(conn, next).Find(noContext, item.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := found.Data, item.Data; got != want {
+		t.Errorf("Want secret data %q, got %q", want, got)
+	}
+}
+
+func TestRekey_Rollback(t *testing.T) {
+	conn, err := dbtest.Connect()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer func() {
+		dbtest.Reset(conn)
+		dbtest.Disconnect(conn)
+	}()
+
+	repo := &core.Repository{UID: "1", Slug: "octocat/hello-world"}
+	if err := repos.New(conn).Create(noContext, repo); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// seeds the database with a secret encrypted using the
+	// previous key, and a secret encrypted using an unknown
+	// key that cannot be decrypted.
+	prev, _ := encrypt.New("ea1c5a9145c8a5ce8231f8b186dbcabc")
+	item := &core.Secret{RepoID: repo.ID, Name: "password", Data: "correct-horse-battery-staple"}
+	if err := secret.New(conn, prev).Create(noContext, item); err != nil {
+		t.Error(err)
+		return
+	}
+	unknown, _ := encrypt.New("0c3d9a82b7e5f6a1c4d8e2f0b9a7c6d5")
+	other := &core.Secret{RepoID: repo.ID, Name: "token", Data: "f0e4c2f76c58916ec258"}
+	if err := secret.New(conn, unknown).Create(noContext, other); err != nil {
+		t.Error(err)
+		return
+	}
+
+	enc, _ := encrypt.New(
+		"fb4b4d6267c8a5ce8231f8b186dbca92",
+		"ea1c5a9145c8a5ce8231f8b186dbcabc",
+	)
+	if _, err := Rekey(noContext, conn, enc, Secrets...); err == nil {
+		t.Errorf("Want error when a value cannot be decrypted")
+	}
+
+	// the transaction is rolled back, so the first secret
+	// is still encrypted with the previous key.
+	found, err := secret.New(conn, prev).Find(noContext, item.ID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := found.Data, item.Data; got != want {
+		t.Errorf("Want secret data %q, got %q", want, got)
+	}
+}
+
+func TestRekey_Compat(t *testing.T) {
+	enc, _ := encrypt.New("fb4b4d6267c8a5ce8231f8b186dbca92")
+	enc.(*encrypt.Aesgcm).Compat = true
+	if _, err := Rekey(noContext, nil, enc, Secrets...); err != ErrCompat {
+		t.Errorf("Want ErrCompat, got %v", err)
+	}
+}
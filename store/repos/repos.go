@@ -32,6 +32,8 @@ type repoStore struct {
 
 func (s *repoStore) List(ctx context.Context, id int64) ([]*core.Repository, error) {
 	var out []*core.Repository
+	// read from the primary, since the list is returned
+	// immediately after the user repositories are synced.
 	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{"user_id": id}
 		query, args, err := binder.BindNamed(queryPerms, params)
@@ -75,7 +77,7 @@ func (s *repoStore) ListLatest(ctx context.Context, id int64) ([]*core.Repositor
 
 func (s *repoStore) ListRecent(ctx context.Context, id int64) ([]*core.Repository, error) {
 	var out []*core.Repository
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{"user_id": id}
 		query, args, err := binder.BindNamed(queryRepoWithBuildAll, params)
 		if err != nil {
@@ -119,7 +121,7 @@ func (s *repoStore) ListRunningStatus(ctx context.Context) ([]*core.RepoBuildSta
 
 func (s *repoStore) ListAll(ctx context.Context, limit, offset int) ([]*core.Repository, error) {
 	var out []*core.Repository
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{
 			"limit":  limit,
 			"offset": offset,
@@ -138,6 +140,27 @@ func (s *repoStore) ListAll(ctx context.Context, limit, offset int) ([]*core.Rep
 	return out, err
 }
 
+func (s *repoStore) ListAfter(ctx context.Context, cursor int64, limit int) ([]*core.Repository, error) {
+	var out []*core.Repository
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
+		params := map[string]interface{}{
+			"repo_id": cursor,
+			"limit":   limit,
+		}
+		query, args, err := binder.BindNamed(queryAfter, params)
+		if err != nil {
+			return err
+		}
+		rows, err := queryer.Query(query, args...)
+		if err != nil {
+			return err
+		}
+		out, err = scanRows(rows)
+		return err
+	})
+	return out, err
+}
+
 func (s *repoStore) Find(ctx context.Context, id int64) (*core.Repository, error) {
 	out := &core.Repository{ID: id}
 	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
@@ -246,6 +269,8 @@ func (s *repoStore) Delete(ctx context.Context, repo *core.Repository) error {
 }
 
 func (s *repoStore) Count(ctx context.Context) (i int64, err error) {
+	// read from the primary, since the count is used to
+	// enforce license limits.
 	err = s.db.View(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{"repo_active": true}
 		query, args, _ := binder.BindNamed(queryCount, params)
@@ -368,9 +393,17 @@ ORDER BY repo_slug ASC
 
 const queryAll = queryCols + `
 FROM repos
+ORDER BY repo_id ASC
 LIMIT :limit OFFSET :offset
 `
 
+const queryAfter = queryCols + `
+FROM repos
+WHERE repo_id > :repo_id
+ORDER BY repo_id ASC
+LIMIT :limit
+`
+
 const stmtDelete = `
 DELETE FROM repos WHERE repo_id = :repo_id
 `
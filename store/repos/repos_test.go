@@ -40,6 +40,7 @@ func TestRepo(t *testing.T) {
 	t.Run("FindName", testRepoFindName(store))
 	t.Run("List", testRepoList(store))
 	t.Run("ListLatest", testRepoListLatest(store))
+	t.Run("ListAfter", testRepoListAfter(store))
 	t.Run("Update", testRepoUpdate(store))
 	t.Run("Activate", testRepoActivate(store))
 	t.Run("Locking", testRepoLocking(store))
@@ -103,6 +104,36 @@ func testRepoCount(repos *repoStore) func(t *testing.T) {
 	}
 }
 
+func testRepoListAfter(repos *repoStore) func(t *testing.T) {
+	return func(t *testing.T) {
+		named, err := repos.FindName(noContext, "octocat", "hello-world")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		list, err := repos.ListAfter(noContext, 0, 25)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := len(list), 1; got != want {
+			t.Errorf("Want list count %d, got %d", want, got)
+			return
+		}
+		if got, want := list[0].ID, named.ID; got != want {
+			t.Errorf("Want repository id %d, got %d", want, got)
+		}
+		list, err = repos.ListAfter(noContext, named.ID, 25)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got, want := len(list), 0; got != want {
+			t.Errorf("Want list count %d, got %d", want, got)
+		}
+	}
+}
+
 func testRepoFind(repos *repoStore) func(t *testing.T) {
 	return func(t *testing.T) {
 		named, err := repos.FindName(noContext, "octocat", "hello-world")
@@ -58,6 +58,31 @@ func Connect(driver, datasource string, maxOpenConnections int) (*DB, error) {
 	}, nil
 }
 
+// ConnectReplica connects to a read-only replica of the database
+// and verifies with a ping. Queries executed with ViewReplica are
+// routed to the replica. The replica is never migrated.
+func (db *DB) ConnectReplica(datasource string, maxOpenConnections int) error {
+	if db.driver == Sqlite {
+		return ErrReplicaUnsupported
+	}
+	driver := db.conn.DriverName()
+	conn, err := sql.Open(driver, datasource)
+	if err != nil {
+		return err
+	}
+	switch driver {
+	case "mysql":
+		conn.SetMaxIdleConns(0)
+	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return err
+	}
+	conn.SetMaxOpenConns(maxOpenConnections)
+	db.replica = sqlx.NewDb(conn, driver)
+	return nil
+}
+
 // helper function to ping the database with backoff to ensure
 // a connection can be established before we proceed with the
 // database setup and migration.
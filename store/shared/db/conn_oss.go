@@ -43,3 +43,9 @@ func Connect(driver, datasource string, maxOpenConnections int) (*DB, error) {
 		driver: Sqlite,
 	}, nil
 }
+
+// ConnectReplica returns an error. Read replicas are not
+// supported with an embedded sqlite database.
+func (db *DB) ConnectReplica(datasource string, maxOpenConnections int) error {
+	return ErrReplicaUnsupported
+}
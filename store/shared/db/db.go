@@ -15,6 +15,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"runtime/debug"
 
@@ -68,9 +69,10 @@ type (
 	// DB is a pool of zero or more underlying connections to
 	// the drone database.
 	DB struct {
-		conn   *sqlx.DB
-		lock   Locker
-		driver Driver
+		conn    *sqlx.DB
+		replica *sqlx.DB
+		lock    Locker
+		driver  Driver
 	}
 )
 
@@ -84,6 +86,23 @@ func (db *DB) View(fn func(Queryer, Binder) error) error {
 	return err
 }
 
+// ViewReplica executes a function against the read replica, if
+// configured, and otherwise against the primary database. If the
+// replica returns an error the function is executed again against
+// the primary database. It should only be used for queries that
+// tolerate replication lag, such as paginated lists that are not
+// read immediately after a write.
+func (db *DB) ViewReplica(fn func(Queryer, Binder) error) error {
+	if db.replica == nil {
+		return db.View(fn)
+	}
+	err := fn(db.replica, db.replica)
+	if err == nil || err == sql.ErrNoRows {
+		return err
+	}
+	return db.View(fn)
+}
+
 // Lock obtains a write lock to the database (sqlite only) and executes
 // a function. Any error that is returned from the function is returned
 // from the Lock() method.
@@ -128,7 +147,15 @@ func (db *DB) Driver() Driver {
 	return db.driver
 }
 
+// Ping verifies the connection to the database is alive.
+func (db *DB) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
 // Close closes the database connection.
 func (db *DB) Close() error {
+	if db.replica != nil {
+		db.replica.Close()
+	}
 	return db.conn.Close()
 }
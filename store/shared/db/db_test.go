@@ -3,3 +3,51 @@
 // that can be found in the LICENSE file.
 
 package db
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestViewReplica(t *testing.T) {
+	// each in-memory sqlite connection is a separate database,
+	// so the pools are limited to a single connection.
+	primary := sqlx.MustOpen("sqlite3", ":memory:")
+	primary.SetMaxOpenConns(1)
+	primary.MustExec("CREATE TABLE t (name TEXT)")
+	primary.MustExec("INSERT INTO t VALUES ('primary')")
+	defer primary.Close()
+
+	replica := sqlx.MustOpen("sqlite3", ":memory:")
+	replica.SetMaxOpenConns(1)
+	defer replica.Close()
+
+	db := &DB{conn: primary, lock: &sync.RWMutex{}, driver: Sqlite}
+	find := func() (name string, err error) {
+		err = db.ViewReplica(func(queryer Queryer, binder Binder) error {
+			return queryer.QueryRow("SELECT name FROM t").Scan(&name)
+		})
+		return
+	}
+
+	// without a replica the query runs against the primary.
+	if name, err := find(); err != nil || name != "primary" {
+		t.Errorf("Want primary, got %q, %v", name, err)
+	}
+
+	// the replica table is missing, the query fails and
+	// is retried against the primary.
+	db.replica = replica
+	if name, err := find(); err != nil || name != "primary" {
+		t.Errorf("Want fallback to primary, got %q, %v", name, err)
+	}
+
+	replica.MustExec("CREATE TABLE t (name TEXT)")
+	replica.MustExec("INSERT INTO t VALUES ('replica')")
+	if name, err := find(); err != nil || name != "replica" {
+		t.Errorf("Want replica, got %q, %v", name, err)
+	}
+}
@@ -59,6 +59,8 @@ func Reset(d *db.DB) {
 		tx.Exec("DELETE FROM users")
 		tx.Exec("DELETE FROM templates")
 		tx.Exec("DELETE FROM orgsecrets")
+		tx.Exec("DELETE FROM banners")
+		tx.Exec("DELETE FROM maintenance")
 		return nil
 	})
 }
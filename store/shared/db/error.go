@@ -20,3 +20,7 @@ import "errors"
 // modified has a Version field and the value is not equal
 // to the current value in the database
 var ErrOptimisticLock = errors.New("Optimistic Lock Error")
+
+// ErrReplicaUnsupported is returned when a read replica is
+// configured for a database that does not support one.
+var ErrReplicaUnsupported = errors.New("Read Replica Not Supported")
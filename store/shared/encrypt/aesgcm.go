@@ -15,22 +15,51 @@
 package encrypt
 
 import (
+	"bytes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"errors"
 	"io"
 )
 
+// header is prepended to ciphertext that embeds the identifier
+// of the key used to encrypt it. The header is only written when
+// previous keys are configured; otherwise the ciphertext uses the
+// original format, which is the nonce followed by the sealed data.
+//
+// Older versions of the server cannot decrypt ciphertext that
+// includes the header, since they treat the header as part of
+// the nonce. Values written while a key rotation is configured
+// cannot be read after a downgrade until they are re-encrypted.
+var header = []byte{'d', 'k', 0x01}
+
+// keyIDSize is the size of the key identifier, in bytes.
+const keyIDSize = 4
+
+var errMalformed = errors.New("malformed ciphertext")
+
+// cipherKey is an encryption key and its identifier.
+type cipherKey struct {
+	id    []byte
+	block cipher.Block
+}
+
 // Aesgcm provides an encrypter that uses the aesgcm encryption
-// algorithm.
+// algorithm. The first key is used to encrypt; all keys are
+// used to decrypt, which allows the primary key to be rotated
+// without losing access to existing ciphertext.
 type Aesgcm struct {
-	block  cipher.Block
+	keys   []*cipherKey
 	Compat bool
 }
 
-// Encrypt encrypts the plaintext using aesgcm.
+// Encrypt encrypts the plaintext using aesgcm. If previous keys
+// are configured, the ciphertext embeds the identifier of the
+// primary key, so that decryption does not need to try each key.
 func (e *Aesgcm) Encrypt(plaintext string) ([]byte, error) {
-	gcm, err := cipher.NewGCM(e.block)
+	primary := e.keys[0]
+	gcm, err := cipher.NewGCM(primary.block)
 	if err != nil {
 		return nil, err
 	}
@@ -41,33 +70,18 @@ func (e *Aesgcm) Encrypt(plaintext string) ([]byte, error) {
 		return nil, err
 	}
 
-	return gcm.Seal(nonce, nonce, []byte(plaintext), nil), nil
+	out := make([]byte, 0, len(header)+keyIDSize+len(nonce)+len(plaintext)+gcm.Overhead())
+	if len(e.keys) > 1 {
+		out = append(out, header...)
+		out = append(out, primary.id...)
+	}
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, []byte(plaintext), nil), nil
 }
 
 // Decrypt decrypts the ciphertext using aesgcm.
 func (e *Aesgcm) Decrypt(ciphertext []byte) (string, error) {
-	gcm, err := cipher.NewGCM(e.block)
-	if err != nil {
-		return "", err
-	}
-
-	if len(ciphertext) < gcm.NonceSize() {
-		// if the decryption utility is running in compatibility
-		// mode, it will return the ciphertext as plain text if
-		// decryption fails. This should be used when running the
-		// database in mixed-mode, where there is a mix of encrypted
-		// and unencrypted content.
-		if e.Compat {
-			return string(ciphertext), nil
-		}
-		return "", errors.New("malformed ciphertext")
-	}
-
-	plaintext, err := gcm.Open(nil,
-		ciphertext[:gcm.NonceSize()],
-		ciphertext[gcm.NonceSize():],
-		nil,
-	)
+	plaintext, err := e.decrypt(ciphertext)
 	// if the decryption utility is running in compatibility
 	// mode, it will return the ciphertext as plain text if
 	// decryption fails. This should be used when running the
@@ -76,5 +90,70 @@ func (e *Aesgcm) Decrypt(ciphertext []byte) (string, error) {
 	if err != nil && e.Compat {
 		return string(ciphertext), nil
 	}
+	return plaintext, err
+}
+
+func (e *Aesgcm) decrypt(ciphertext []byte) (string, error) {
+	if id, payload, ok := split(ciphertext); ok {
+		for _, k := range e.keys {
+			if !bytes.Equal(k.id, id) {
+				continue
+			}
+			if plaintext, err := open(k.block, payload); err == nil {
+				return plaintext, nil
+			}
+		}
+	}
+
+	// the ciphertext does not embed a known key identifier,
+	// which is expected for ciphertext created before key
+	// rotation was supported. Fallback to trying every key.
+	err := errMalformed
+	for _, k := range e.keys {
+		var plaintext string
+		plaintext, err = open(k.block, ciphertext)
+		if err == nil {
+			return plaintext, nil
+		}
+	}
+	return "", err
+}
+
+// helper function splits the ciphertext into the key
+// identifier and the encrypted payload.
+func split(ciphertext []byte) (id, payload []byte, ok bool) {
+	if !bytes.HasPrefix(ciphertext, header) {
+		return nil, nil, false
+	}
+	ciphertext = ciphertext[len(header):]
+	if len(ciphertext) < keyIDSize {
+		return nil, nil, false
+	}
+	return ciphertext[:keyIDSize], ciphertext[keyIDSize:], true
+}
+
+// helper function decrypts the payload using the block
+// cipher, where the payload is the nonce followed by
+// the sealed data.
+func open(block cipher.Block, payload []byte) (string, error) {
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(payload) < gcm.NonceSize() {
+		return "", errMalformed
+	}
+	plaintext, err := gcm.Open(nil,
+		payload[:gcm.NonceSize()],
+		payload[gcm.NonceSize():],
+		nil,
+	)
 	return string(plaintext), err
 }
+
+// helper function returns the key identifier, which is
+// derived from a hash of the key.
+func keyID(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:keyIDSize]
+}
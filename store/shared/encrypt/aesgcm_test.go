@@ -4,7 +4,12 @@
 
 package encrypt
 
-import "testing"
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+)
 
 func TestAesgcm(t *testing.T) {
 	s := "correct-horse-batter-staple"
@@ -53,3 +58,114 @@ func TestAesgcmCompat(t *testing.T) {
 		t.Errorf("Want plaintext %q, got %q", want, got)
 	}
 }
+
+func TestAesgcmRotate(t *testing.T) {
+	s := "correct-horse-batter-staple"
+	n, _ := New("ea1c5a9145c8a5ce8231f8b186dbcabc")
+	ciphertext, err := n.Encrypt(s)
+	if err != nil {
+		t.Error(err)
+	}
+	n, _ = New("fb4b4d6267c8a5ce8231f8b186dbca92", "ea1c5a9145c8a5ce8231f8b186dbcabc")
+	plaintext, err := n.Decrypt(ciphertext)
+	if err != nil {
+		t.Error(err)
+	}
+	if want, got := plaintext, s; got != want {
+		t.Errorf("Want plaintext %q, got %q", want, got)
+	}
+
+	// ciphertext must be encrypted with the primary key
+	// and decrypted without the previous key.
+	ciphertext, err = n.Encrypt(s)
+	if err != nil {
+		t.Error(err)
+	}
+	n, _ = New("fb4b4d6267c8a5ce8231f8b186dbca92")
+	plaintext, err = n.Decrypt(ciphertext)
+	if err != nil {
+		t.Error(err)
+	}
+	if want, got := plaintext, s; got != want {
+		t.Errorf("Want plaintext %q, got %q", want, got)
+	}
+}
+
+func TestAesgcmKeyID(t *testing.T) {
+	n, _ := New("fb4b4d6267c8a5ce8231f8b186dbca92", "ea1c5a9145c8a5ce8231f8b186dbcabc")
+	ciphertext, err := n.Encrypt("correct-horse-batter-staple")
+	if err != nil {
+		t.Error(err)
+	}
+	id, _, ok := split(ciphertext)
+	if !ok {
+		t.Errorf("Expect ciphertext includes key identifier")
+	}
+	if want, got := keyID([]byte("fb4b4d6267c8a5ce8231f8b186dbca92")), id; !bytes.Equal(got, want) {
+		t.Errorf("Want key identifier %x, got %x", want, got)
+	}
+}
+
+// this test verifies ciphertext uses the original format when
+// no previous keys are configured, so that it can be decrypted
+// by older versions of the server.
+func TestAesgcmNoKeyID(t *testing.T) {
+	s := "correct-horse-batter-staple"
+	b := []byte("fb4b4d6267c8a5ce8231f8b186dbca92")
+	n, _ := New(string(b))
+	ciphertext, err := n.Encrypt(s)
+	if err != nil {
+		t.Error(err)
+	}
+	if bytes.HasPrefix(ciphertext, header) {
+		t.Errorf("Expect ciphertext without key identifier")
+	}
+
+	block, _ := aes.NewCipher(b)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil,
+		ciphertext[:gcm.NonceSize()],
+		ciphertext[gcm.NonceSize():],
+		nil,
+	)
+	if err != nil {
+		t.Error(err)
+	}
+	if want, got := string(plaintext), s; got != want {
+		t.Errorf("Want plaintext %q, got %q", want, got)
+	}
+}
+
+// this test verifies ciphertext created before key identifiers
+// were embedded in the ciphertext can still be decrypted.
+func TestAesgcmLegacy(t *testing.T) {
+	s := "correct-horse-batter-staple"
+	b := []byte("ea1c5a9145c8a5ce8231f8b186dbcabc")
+	block, _ := aes.NewCipher(b)
+	gcm, _ := cipher.NewGCM(block)
+	nonce := make([]byte, gcm.NonceSize())
+	ciphertext := gcm.Seal(nonce, nonce, []byte(s), nil)
+
+	n, _ := New("fb4b4d6267c8a5ce8231f8b186dbca92", string(b))
+	plaintext, err := n.Decrypt(ciphertext)
+	if err != nil {
+		t.Error(err)
+	}
+	if want, got := plaintext, s; got != want {
+		t.Errorf("Want plaintext %q, got %q", want, got)
+	}
+}
+
+func TestAesgcmKeySize(t *testing.T) {
+	_, err := New("fb4b4d6267c8a5ce8231f8b186dbca92", "ea1c5a91")
+	if err != errKeySize {
+		t.Errorf("Expect error when previous key is invalid")
+	}
+}
+
+func TestAesgcmKeyMissing(t *testing.T) {
+	_, err := New("", "ea1c5a9145c8a5ce8231f8b186dbcabc")
+	if err != errKeyMissing {
+		t.Errorf("Expect error when previous keys are set without a key")
+	}
+}
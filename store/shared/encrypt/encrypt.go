@@ -22,6 +22,9 @@ import (
 // indicates key size is too small.
 var errKeySize = errors.New("encryption key must be 32 bytes")
 
+// indicates previous keys are configured without a key.
+var errKeyMissing = errors.New("encryption key must be set when previous keys are set")
+
 // Encrypter provides database field encryption and decryption.
 // Encrypted values are currently limited to strings, which is
 // reflected in the interface design.
@@ -30,18 +33,27 @@ type Encrypter interface {
 	Decrypt(ciphertext []byte) (string, error)
 }
 
-// New provides a new database field encrypter.
-func New(key string) (Encrypter, error) {
+// New provides a new database field encrypter that encrypts
+// with the key. Previous keys are only used to decrypt
+// ciphertext that was encrypted before the key was rotated.
+func New(key string, previous ...string) (Encrypter, error) {
+	if key == "" && len(previous) != 0 {
+		return nil, errKeyMissing
+	}
 	if key == "" {
 		return &none{}, nil
 	}
-	if len(key) != 32 {
-		return nil, errKeySize
-	}
-	b := []byte(key)
-	block, err := aes.NewCipher(b)
-	if err != nil {
-		return nil, err
+	enc := new(Aesgcm)
+	for _, k := range append([]string{key}, previous...) {
+		if len(k) != 32 {
+			return nil, errKeySize
+		}
+		b := []byte(k)
+		block, err := aes.NewCipher(b)
+		if err != nil {
+			return nil, err
+		}
+		enc.keys = append(enc.keys, &cipherKey{id: keyID(b), block: block})
 	}
-	return &Aesgcm{block: block}, nil
+	return enc, nil
 }
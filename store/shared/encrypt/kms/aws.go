@@ -0,0 +1,60 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// NewAWSEnv returns a new AWS KMS key management service,
+// with credentials and region loaded from the environment.
+func NewAWSEnv(client *http.Client) (Service, error) {
+	sess, err := session.NewSession(&aws.Config{HTTPClient: client})
+	if err != nil {
+		return nil, err
+	}
+	return NewAWS(sess), nil
+}
+
+// NewAWS returns a new AWS KMS key management service.
+func NewAWS(sess *session.Session) Service {
+	return &awsService{client: kms.New(sess)}
+}
+
+type awsService struct {
+	client *kms.KMS
+}
+
+// Decrypt decrypts the base64-encoded ciphertext blob. The key
+// used to encrypt the blob is identified by the blob metadata.
+func (s *awsService) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	blob, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	out, err := s.client.DecryptWithContext(ctx, &kms.DecryptInput{
+		CiphertextBlob: blob,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out.Plaintext), nil
+}
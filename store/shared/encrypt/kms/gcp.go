@@ -0,0 +1,126 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	gcpEndpoint = "https://cloudkms.googleapis.com"
+	gcpMetadata = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// NewGCP returns a new Google Cloud KMS key management
+// service, where key is the resource name of the key in
+// the projects/*/locations/*/keyRings/*/cryptoKeys/* format.
+// If the access token is empty, a token is requested from
+// the metadata server of the compute instance.
+func NewGCP(client *http.Client, key, token string) Service {
+	return &gcpService{
+		client:   client,
+		endpoint: gcpEndpoint,
+		metadata: gcpMetadata,
+		key:      key,
+		token:    token,
+	}
+}
+
+type gcpService struct {
+	client   *http.Client
+	endpoint string
+	metadata string
+	key      string
+	token    string
+}
+
+// Decrypt decrypts the base64-encoded ciphertext using the
+// Cloud KMS key.
+func (s *gcpService) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	in := struct {
+		Ciphertext string `json:"ciphertext"`
+	}{ciphertext}
+	b, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+
+	uri := fmt.Sprintf("%s/v1/%s:decrypt", s.endpoint, s.key)
+	req, err := http.NewRequest("POST", uri, bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	out := struct {
+		Plaintext string `json:"plaintext"`
+	}{}
+	if err := s.do(req, &out); err != nil {
+		return "", err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(out.Plaintext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// helper function returns the configured access token, or
+// requests an access token from the metadata server.
+func (s *gcpService) accessToken(ctx context.Context) (string, error) {
+	if s.token != "" {
+		return s.token, nil
+	}
+	req, err := http.NewRequest("GET", s.metadata, nil)
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	out := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := s.do(req, &out); err != nil {
+		return "", err
+	}
+	return out.AccessToken, nil
+}
+
+// helper function sends the request and decodes the json
+// response body.
+func (s *gcpService) do(req *http.Request, out interface{}) error {
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode > 299 {
+		return fmt.Errorf("kms: gcp responded with status code %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGCP(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Metadata-Flavor"), "Google"; got != want {
+			t.Errorf("Want metadata flavor header %q, got %q", want, got)
+		}
+		w.Write([]byte(`{"access_token":"ya29.c3a9f0e","token_type":"Bearer"}`))
+	})
+	mux.HandleFunc("/v1/projects/drone/locations/global/keyRings/drone/cryptoKeys/database:decrypt", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer ya29.c3a9f0e"; got != want {
+			t.Errorf("Want authorization header %q, got %q", want, got)
+		}
+		in := struct {
+			Ciphertext string `json:"ciphertext"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&in)
+		if got, want := in.Ciphertext, "CiQAqD+xX8aKz4MNsYbEVXYIhWpVbMAm"; got != want {
+			t.Errorf("Want ciphertext %q, got %q", want, got)
+		}
+		plaintext := base64.StdEncoding.EncodeToString([]byte("fb4b4d6267c8a5ce8231f8b186dbca92"))
+		w.Write([]byte(`{"plaintext":"` + plaintext + `"}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	service := NewGCP(ts.Client(), "projects/drone/locations/global/keyRings/drone/cryptoKeys/database", "").(*gcpService)
+	service.endpoint = ts.URL
+	service.metadata = ts.URL + "/token"
+
+	plaintext, err := service.Decrypt(context.Background(), "CiQAqD+xX8aKz4MNsYbEVXYIhWpVbMAm")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := plaintext, "fb4b4d6267c8a5ce8231f8b186dbca92"; got != want {
+		t.Errorf("Want plaintext %q, got %q", want, got)
+	}
+}
+
+func TestGCP_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	}))
+	defer ts.Close()
+
+	service := NewGCP(ts.Client(), "projects/drone/locations/global/keyRings/drone/cryptoKeys/database", "ya29.c3a9f0e").(*gcpService)
+	service.endpoint = ts.URL
+
+	_, err := service.Decrypt(context.Background(), "CiQAqD+xX8aKz4MNsYbEVXYIhWpVbMAm")
+	if err == nil {
+		t.Errorf("Expect error when cloud kms returns a non-2xx status code")
+	}
+}
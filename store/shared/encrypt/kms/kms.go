@@ -0,0 +1,97 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kms decrypts the database encryption key using an
+// external key management service, so that the plaintext key
+// never needs to be stored on disk or in the environment.
+package kms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrUnknownProvider is returned when the key management
+// service provider is not supported.
+var ErrUnknownProvider = errors.New("kms: unknown key management service")
+
+// Service decrypts an encryption key that was encrypted by
+// an external key management service.
+type Service interface {
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+// Config provides the key management service configuration.
+type Config struct {
+	// Provider is the name of the key management service
+	// provider (awskms, gcpkms or vault).
+	Provider string
+
+	// Timeout is the maximum duration of a request to the
+	// key management service.
+	Timeout time.Duration
+
+	// GCPKey is the resource name of the Cloud KMS key.
+	// GCPToken is an optional OAuth2 access token; if empty
+	// a token is requested from the metadata server.
+	GCPKey   string
+	GCPToken string
+
+	// Vault transit secrets engine configuration.
+	VaultAddr  string
+	VaultToken string
+	VaultMount string
+	VaultKey   string
+}
+
+// New returns a new key management Service for the configured
+// provider. AWS KMS credentials and region are loaded from the
+// environment by the AWS SDK.
+func New(config Config) (Service, error) {
+	client := &http.Client{Timeout: config.Timeout}
+	switch config.Provider {
+	case "awskms":
+		return NewAWSEnv(client)
+	case "gcpkms":
+		return NewGCP(client, config.GCPKey, config.GCPToken), nil
+	case "vault":
+		return NewVault(client,
+			config.VaultAddr,
+			config.VaultToken,
+			config.VaultMount,
+			config.VaultKey,
+		), nil
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+// DecryptAll decrypts each of the encryption keys.
+func DecryptAll(ctx context.Context, service Service, ciphertext ...string) ([]string, error) {
+	var out []string
+	for _, s := range ciphertext {
+		if s == "" {
+			out = append(out, s)
+			continue
+		}
+		plaintext, err := service.Decrypt(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, plaintext)
+	}
+	return out, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 Drone IO, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NewVault returns a new HashiCorp Vault transit key
+// management service.
+func NewVault(client *http.Client, addr, token, mount, key string) Service {
+	if mount == "" {
+		mount = "transit"
+	}
+	return &vaultService{
+		client: client,
+		addr:   strings.TrimSuffix(addr, "/"),
+		token:  token,
+		mount:  mount,
+		key:    key,
+	}
+}
+
+type vaultService struct {
+	client *http.Client
+	addr   string
+	token  string
+	mount  string
+	key    string
+}
+
+// Decrypt decrypts the ciphertext using the transit secrets
+// engine, where the ciphertext is in the vault:v1:... format.
+func (s *vaultService) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	in := struct {
+		Ciphertext string `json:"ciphertext"`
+	}{ciphertext}
+	b, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+
+	uri := fmt.Sprintf("%s/v1/%s/decrypt/%s", s.addr, s.mount, s.key)
+	req, err := http.NewRequest("POST", uri, bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", s.token)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode > 299 {
+		return "", fmt.Errorf("kms: vault responded with status code %d", res.StatusCode)
+	}
+
+	out := struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(out.Data.Plaintext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by the Drone Non-Commercial License
+// that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVault(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, "/v1/transit/decrypt/drone"; got != want {
+			t.Errorf("Want request path %q, got %q", want, got)
+		}
+		if got, want := r.Header.Get("X-Vault-Token"), "s.3a9f0e"; got != want {
+			t.Errorf("Want vault token %q, got %q", want, got)
+		}
+		in := struct {
+			Ciphertext string `json:"ciphertext"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&in)
+		if got, want := in.Ciphertext, "vault:v1:8SDd3WHDOjf7mq69CyCqYjBXAiQQAVZRkFM13ok481zoCmHnSeDX9vyf7w=="; got != want {
+			t.Errorf("Want ciphertext %q, got %q", want, got)
+		}
+		plaintext := base64.StdEncoding.EncodeToString([]byte("fb4b4d6267c8a5ce8231f8b186dbca92"))
+		w.Write([]byte(`{"data":{"plaintext":"` + plaintext + `"}}`))
+	}))
+	defer ts.Close()
+
+	service := NewVault(ts.Client(), ts.URL, "s.3a9f0e", "transit", "drone")
+	plaintext, err := service.Decrypt(context.Background(), "vault:v1:8SDd3WHDOjf7mq69CyCqYjBXAiQQAVZRkFM13ok481zoCmHnSeDX9vyf7w==")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := plaintext, "fb4b4d6267c8a5ce8231f8b186dbca92"; got != want {
+		t.Errorf("Want plaintext %q, got %q", want, got)
+	}
+}
+
+func TestVault_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(403)
+	}))
+	defer ts.Close()
+
+	service := NewVault(ts.Client(), ts.URL, "s.3a9f0e", "transit", "drone")
+	_, err := service.Decrypt(context.Background(), "vault:v1:8SDd3WHDOjf7mq69CyCqYjBXAiQQAVZRkFM13ok481zoCmHnSeDX9vyf7w==")
+	if err == nil {
+		t.Errorf("Expect error when vault returns a non-2xx status code")
+	}
+}
+
+func TestVault_DefaultMount(t *testing.T) {
+	service := NewVault(http.DefaultClient, "https://vault.company.com/", "s.3a9f0e", "", "drone").(*vaultService)
+	if got, want := service.mount, "transit"; got != want {
+		t.Errorf("Want mount %q, got %q", want, got)
+	}
+	if got, want := service.addr, "https://vault.company.com"; got != want {
+		t.Errorf("Want address %q, got %q", want, got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	service, err := New(Config{Provider: "vault", Timeout: time.Minute})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := service.(*vaultService).client.Timeout, time.Minute; got != want {
+		t.Errorf("Want client timeout %v, got %v", want, got)
+	}
+}
+
+func TestNew_Unknown(t *testing.T) {
+	_, err := New(Config{Provider: "azurekv"})
+	if err != ErrUnknownProvider {
+		t.Errorf("Expect unknown provider error")
+	}
+}
@@ -0,0 +1,3 @@
+-- name: create-index-builds-sender-id
+
+CREATE INDEX ix_build_sender_id ON builds (build_sender, build_id);
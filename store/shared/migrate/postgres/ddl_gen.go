@@ -200,6 +200,18 @@ var migrations = []struct {
 		name: "create-new-table-cards",
 		stmt: createNewTableCards,
 	},
+	{
+		name: "create-table-banners",
+		stmt: createTableBanners,
+	},
+	{
+		name: "create-table-maintenance",
+		stmt: createTableMaintenance,
+	},
+	{
+		name: "create-index-builds-sender-id",
+		stmt: createIndexBuildsSenderId,
+	},
 }
 
 // Migrate performs the database migration. If the migration fails
@@ -769,3 +781,41 @@ CREATE TABLE IF NOT EXISTS cards
     FOREIGN KEY (card_id) REFERENCES steps (step_id) ON DELETE CASCADE
 );
 `
+
+//
+// 020_create_table_banners.sql
+//
+
+var createTableBanners = `
+CREATE TABLE IF NOT EXISTS banners (
+     banner_id          SERIAL PRIMARY KEY
+    ,banner_message     TEXT
+    ,banner_level       VARCHAR(50)
+    ,banner_dismissible BOOLEAN
+    ,banner_starts      BIGINT
+    ,banner_ends        BIGINT
+    ,banner_created     INTEGER
+    ,banner_updated     INTEGER
+);
+`
+
+//
+// 021_create_table_maintenance.sql
+//
+
+var createTableMaintenance = `
+CREATE TABLE IF NOT EXISTS maintenance (
+     maintenance_id      INTEGER PRIMARY KEY
+    ,maintenance_enabled BOOLEAN
+    ,maintenance_message TEXT
+    ,maintenance_updated BIGINT
+);
+`
+
+//
+// 022_create_index_builds_sender_id.sql
+//
+
+var createIndexBuildsSenderId = `
+CREATE INDEX IF NOT EXISTS ix_build_sender_id ON builds (build_sender, build_id);
+`
@@ -0,0 +1,12 @@
+-- name: create-table-banners
+
+CREATE TABLE IF NOT EXISTS banners (
+     banner_id          SERIAL PRIMARY KEY
+    ,banner_message     TEXT
+    ,banner_level       VARCHAR(50)
+    ,banner_dismissible BOOLEAN
+    ,banner_starts      BIGINT
+    ,banner_ends        BIGINT
+    ,banner_created     INTEGER
+    ,banner_updated     INTEGER
+);
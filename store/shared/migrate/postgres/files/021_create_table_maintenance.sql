@@ -0,0 +1,8 @@
+-- name: create-table-maintenance
+
+CREATE TABLE IF NOT EXISTS maintenance (
+     maintenance_id      INTEGER PRIMARY KEY
+    ,maintenance_enabled BOOLEAN
+    ,maintenance_message TEXT
+    ,maintenance_updated BIGINT
+);
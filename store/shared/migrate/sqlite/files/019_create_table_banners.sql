@@ -0,0 +1,12 @@
+-- name: create-table-banners
+
+CREATE TABLE IF NOT EXISTS banners (
+     banner_id          INTEGER PRIMARY KEY AUTOINCREMENT
+    ,banner_message     TEXT
+    ,banner_level       TEXT
+    ,banner_dismissible BOOLEAN
+    ,banner_starts      INTEGER
+    ,banner_ends        INTEGER
+    ,banner_created     INTEGER
+    ,banner_updated     INTEGER
+);
@@ -81,7 +81,7 @@ func (s *userStore) FindToken(ctx context.Context, token string) (*core.User, er
 // List returns a list of users from the datastore.
 func (s *userStore) List(ctx context.Context) ([]*core.User, error) {
 	var out []*core.User
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		rows, err := queryer.Query(queryAll)
 		if err != nil {
 			return err
@@ -95,7 +95,7 @@ func (s *userStore) List(ctx context.Context) ([]*core.User, error) {
 // ListRange returns a list of users from the datastore.
 func (s *userStore) ListRange(ctx context.Context, params core.UserParams) ([]*core.User, error) {
 	var out []*core.User
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		// this query breaks a rule and uses sprintf to inject parameters
 		// into the query. Normally this should be avoided, however, in this
 		// case the parameters are set by the internal system and can
@@ -199,7 +199,7 @@ func (s *userStore) Count(ctx context.Context) (int64, error) {
 // Count returns a count of active human users.
 func (s *userStore) CountHuman(ctx context.Context) (int64, error) {
 	var out int64
-	err := s.db.View(func(queryer db.Queryer, binder db.Binder) error {
+	err := s.db.ViewReplica(func(queryer db.Queryer, binder db.Binder) error {
 		params := map[string]interface{}{"user_machine": false}
 		stmt, args, err := binder.BindNamed(queryCountHuman, params)
 		if err != nil {
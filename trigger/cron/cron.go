@@ -22,26 +22,29 @@ import (
 func New(
 	commits core.CommitService,
 	cron core.CronStore,
+	maintenance core.MaintenanceService,
 	repos core.RepositoryStore,
 	users core.UserStore,
 	trigger core.Triggerer,
 ) *Scheduler {
 	return &Scheduler{
-		commits: commits,
-		cron:    cron,
-		repos:   repos,
-		users:   users,
-		trigger: trigger,
+		commits:     commits,
+		cron:        cron,
+		maintenance: maintenance,
+		repos:       repos,
+		users:       users,
+		trigger:     trigger,
 	}
 }
 
 // Scheduler defines a cron scheduler.
 type Scheduler struct {
-	commits core.CommitService
-	cron    core.CronStore
-	repos   core.RepositoryStore
-	users   core.UserStore
-	trigger core.Triggerer
+	commits     core.CommitService
+	cron        core.CronStore
+	maintenance core.MaintenanceService
+	repos       core.RepositoryStore
+	users       core.UserStore
+	trigger     core.Triggerer
 }
 
 // Start starts the cron scheduler.
@@ -71,6 +74,22 @@ func (s *Scheduler) run(ctx context.Context) error {
 		}
 	}()
 
+	// pending jobs are not processed while maintenance mode is
+	// enabled. They are processed once maintenance mode is
+	// disabled, since their next execution date is in the past.
+	if s.maintenance != nil {
+		mode, err := s.maintenance.Find(ctx)
+		if err != nil {
+			logger := logrus.WithError(err)
+			logger.Error("cron: cannot find maintenance mode")
+			return err
+		}
+		if mode.Enabled {
+			logrus.Debugln("cron: skip pending jobs in maintenance mode")
+			return nil
+		}
+	}
+
 	now := time.Now()
 	jobs, err := s.cron.Ready(ctx, now.Unix())
 	if err != nil {
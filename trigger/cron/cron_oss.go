@@ -27,6 +27,7 @@ import (
 func New(
 	core.CommitService,
 	core.CronStore,
+	core.MaintenanceService,
 	core.RepositoryStore,
 	core.UserStore,
 	core.Triggerer,
@@ -15,6 +15,7 @@ import (
 
 	"github.com/drone/drone/core"
 	"github.com/drone/drone/mock"
+	"github.com/drone/drone/service/maintenance"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -93,6 +94,29 @@ func TestCron_Cancel(t *testing.T) {
 	}
 }
 
+// This unit tests demonstrates that pending cronjobs are not
+// processed while maintenance mode is enabled.
+func TestCron_Maintenance(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockMaintenance := mock.NewMockMaintenanceStore(controller)
+	mockMaintenance.EXPECT().Find(gomock.Any()).Return(&core.Maintenance{Enabled: true}, nil)
+
+	mockCrons := mock.NewMockCronStore(controller)
+	mockCrons.EXPECT().Ready(gomock.Any(), gomock.Any()).Times(0)
+
+	s := Scheduler{
+		cron:        mockCrons,
+		maintenance: maintenance.New(mockMaintenance),
+	}
+
+	err := s.run(noContext)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 // This unit tests demonstrates that if an error is encountered
 // when returning a list of ready cronjobs, the process exits
 // immediately with an error message.